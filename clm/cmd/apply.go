@@ -30,6 +30,7 @@ import (
 const applyUsage = `Apply component manifests to Kubernetes cluster`
 
 type applyOptions struct {
+	chartSourceFlags
 	valuesSources   []string
 	createNamespace bool
 	timeout         time.Duration
@@ -91,7 +92,12 @@ func newApplyCmd() *cobra.Command {
 
 			release.Revision += 1
 
-			objects, err := manifests.Generate(manifestSources, options.valuesSources, fullName, clnt, release)
+			chartSourceOptions, err := options.chartSourceOptions()
+			if err != nil {
+				return err
+			}
+
+			objects, notes, err := manifests.Generate(manifestSources, options.valuesSources, chartSourceOptions, fullName, clnt, release)
 			if err != nil {
 				return err
 			}
@@ -128,6 +134,7 @@ func newApplyCmd() *cobra.Command {
 					errCount = 0
 					if ok {
 						release.State = component.StateReady
+						release.Notes = notes
 						break
 					}
 					if err := releaseClient.Update(context.TODO(), release); err != nil {
@@ -142,6 +149,7 @@ func newApplyCmd() *cobra.Command {
 			}
 
 			fmt.Printf("Release %s/%s successfully applied\n", release.GetNamespace(), release.GetName())
+			printNotes(release.Notes)
 
 			return nil
 		},
@@ -167,6 +175,7 @@ func newApplyCmd() *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringArrayVarP(&options.valuesSources, "values", "f", nil, "Path to values file in yaml format (can be repeated, values will be merged in order of appearance)")
+	options.addChartSourceFlags(flags)
 	flags.BoolVar(&options.createNamespace, "create-namespace", false, "Create release namespace if not existing")
 	flags.DurationVar(&options.timeout, "timeout", 0, "Time to wait for the operation to complete (default is to wait forever)")
 
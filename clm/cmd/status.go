@@ -75,6 +75,7 @@ func newStatusCmd() *cobra.Command {
 				fmt.Fprintf(w, "%s:\t%s\t\n", "Created at", details.CreatedAt)
 				fmt.Fprintf(w, "%s:\t%s\t\n", "Last updated at", details.LastUpdatedAt)
 				w.Flush()
+				printNotes(details.Notes)
 			case "yaml":
 				fmt.Printf("%s", string(util.Must(kyaml.Marshal(getReleaseDetails(release)))))
 			case "json":
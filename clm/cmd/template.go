@@ -23,6 +23,7 @@ import (
 const templateUsage = `Render component manifests to standard output without applying them to the cluster`
 
 type templateOptions struct {
+	chartSourceFlags
 	valuesSources []string
 }
 
@@ -51,7 +52,12 @@ func newTemplateCmd() *cobra.Command {
 			release := release.NewRelease(namespace, name)
 			release.Revision += 1
 
-			objects, err := manifests.Generate(manifestSources, options.valuesSources, fullName, clnt, release)
+			chartSourceOptions, err := options.chartSourceOptions()
+			if err != nil {
+				return err
+			}
+
+			objects, _, err := manifests.Generate(manifestSources, options.valuesSources, chartSourceOptions, fullName, clnt, release)
 			if err != nil {
 				return err
 			}
@@ -84,6 +90,7 @@ func newTemplateCmd() *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringArrayVarP(&options.valuesSources, "values", "f", nil, "Path to values file in yaml format (can be repeated, values will be merged in order of appearance)")
+	options.addChartSourceFlags(flags)
 
 	return cmd
 }
@@ -8,9 +8,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/sap/go-generics/slices"
+	"github.com/spf13/pflag"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -23,6 +25,7 @@ import (
 	"github.com/sap/component-operator-runtime/clm/internal/release"
 	"github.com/sap/component-operator-runtime/internal/clientfactory"
 	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/manifests/helm"
 	"github.com/sap/component-operator-runtime/pkg/reconciler"
 )
 
@@ -48,6 +51,34 @@ func getClient(kubeConfigPath string) (cluster.Client, error) {
 	return clientfactory.NewClientFor(config, scheme, fullName)
 }
 
+type chartSourceFlags struct {
+	registryConfig string
+	plainHttp      bool
+}
+
+func (f *chartSourceFlags) addChartSourceFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.registryConfig, "registry-config", "", "Path to registry config file (docker config json format) providing credentials for remote chart sources")
+	flags.BoolVar(&f.plainHttp, "plain-http", false, "Use insecure http connections when pulling charts from oci registries")
+}
+
+func (f *chartSourceFlags) chartSourceOptions() (helm.ChartSourceOptions, error) {
+	options := helm.ChartSourceOptions{
+		PlainHTTP: f.plainHttp,
+	}
+	if f.registryConfig != "" {
+		rawConfig, err := os.ReadFile(f.registryConfig)
+		if err != nil {
+			return helm.ChartSourceOptions{}, err
+		}
+		credentials, err := helm.ParseDockerConfig(rawConfig)
+		if err != nil {
+			return helm.ChartSourceOptions{}, fmt.Errorf("error parsing registry config %s: %w", f.registryConfig, err)
+		}
+		options.Credentials = credentials
+	}
+	return options, nil
+}
+
 func isEphmeralError(err error) bool {
 	if apierrors.IsConflict(err) {
 		return true
@@ -55,6 +86,14 @@ func isEphmeralError(err error) bool {
 	return false
 }
 
+// Print release notes, in the same format as helm does.
+func printNotes(notes string) {
+	if notes == "" {
+		return
+	}
+	fmt.Printf("\nNOTES:\n%s\n", strings.TrimRight(notes, "\n"))
+}
+
 func formatTimestamp(t time.Time) string {
 	d := time.Since(t)
 	if d > 24*time.Hour {
@@ -78,6 +117,7 @@ type releaseDetails struct {
 	NumCompletedObjects int64  `json:"numCompletedObjects"`
 	CreatedAt           string `json:"createdAt"`
 	LastUpdatedAt       string `json:"lastUpdatedAt"`
+	Notes               string `json:"notes,omitempty"`
 }
 
 func getReleaseDetails(release *release.Release) *releaseDetails {
@@ -91,5 +131,6 @@ func getReleaseDetails(release *release.Release) *releaseDetails {
 		NumCompletedObjects: int64(slices.Count(release.Inventory, func(item *reconciler.InventoryItem) bool { return item.Phase == reconciler.PhaseCompleted })),
 		CreatedAt:           formatTimestamp(*release.GetCreationTimestamp()),
 		LastUpdatedAt:       formatTimestamp(*release.GetUpdateTimestamp()),
+		Notes:               release.Notes,
 	}
 }
@@ -12,6 +12,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	kyaml "sigs.k8s.io/yaml"
@@ -25,8 +26,9 @@ import (
 	"github.com/sap/component-operator-runtime/pkg/types"
 )
 
-func Generate(manifestSources []string, valuesSources []string, reconcilerName string, clnt cluster.Client, release *release.Release) ([]client.Object, error) {
+func Generate(manifestSources []string, valuesSources []string, chartSourceOptions helm.ChartSourceOptions, reconcilerName string, clnt cluster.Client, release *release.Release) ([]client.Object, string, error) {
 	var allObjects []client.Object
+	var allNotes []string
 	var allValues = make(map[string]any)
 
 	for _, source := range valuesSources {
@@ -35,62 +37,76 @@ func Generate(manifestSources []string, valuesSources []string, reconcilerName s
 
 		rawValues, err := os.ReadFile(path)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		var values map[string]any
 		if err := kyaml.Unmarshal(rawValues, &values); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		manifests.MergeMapInto(allValues, values)
 	}
 
 	for _, source := range manifestSources {
-		// TODO: support helm, oci URLs
-
 		var fsys fs.FS
 		var path string
+		var isChart bool
 
-		source, err := filepath.Abs(source)
-		if err != nil {
-			return nil, err
-		}
-
-		if info, err := os.Stat(source); err != nil {
+		if isRemoteChartSource(source) {
+			chartSource, err := helm.ParseChartSource(source)
+			if err != nil {
+				return nil, "", err
+			}
+			fsys, path, err = helm.FetchChart(context.TODO(), chartSource, chartSourceOptions)
+			if err != nil {
+				return nil, "", fmt.Errorf("error fetching chart %s: %w", source, err)
+			}
+			isChart = true
+		} else if source, err := filepath.Abs(source); err != nil {
+			return nil, "", err
+		} else if info, err := os.Stat(source); err != nil {
 			if errors.Is(err, fs.ErrNotExist) {
-				return nil, fmt.Errorf("no such file or directory: %s", source)
+				return nil, "", fmt.Errorf("no such file or directory: %s", source)
 			} else {
-				return nil, err
+				return nil, "", err
 			}
 		} else if info.IsDir() {
 			fsys = os.DirFS("/")
 			path = source[1:]
+			if _, err := fs.Stat(fsys, filepath.Clean(path+"/Chart.yaml")); err == nil {
+				isChart = true
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, "", err
+			}
+		} else if isChartArchive(source) {
+			fsys = os.DirFS("/")
+			path = source[1:]
+			isChart = true
 		} else {
 			tmpdir, err := os.MkdirTemp("", "clm-")
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
 			defer os.RemoveAll(tmpdir)
 			if _, err := copyFile(source, fmt.Sprintf("%s/%s", tmpdir, "resources.yaml")); err != nil {
-				return nil, err
+				return nil, "", err
 			}
 			fsys = os.DirFS(tmpdir)
 			path = "."
 		}
 
 		var generator manifests.Generator
-		if _, err = fs.Stat(fsys, filepath.Clean(path+"/Chart.yaml")); err == nil {
+		var err error
+		if isChart {
 			generator, err = helm.NewHelmGenerator(fsys, path, nil)
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
-		} else if errors.Is(err, fs.ErrNotExist) {
+		} else {
 			generator, err = kustomize.NewKustomizeGenerator(fsys, path, nil, kustomize.KustomizeGeneratorOptions{})
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
-		} else {
-			return nil, err
 		}
 
 		releaseComponent := componentFromRelease(release, allValues)
@@ -106,11 +122,21 @@ func Generate(manifestSources []string, valuesSources []string, reconcilerName s
 			WithComponentRevision(releaseComponent.Status.Revision)
 		objects, err := generator.Generate(generateCtx, releaseComponent.Namespace, releaseComponent.Name, types.UnstructurableMap(allValues))
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		allObjects = append(allObjects, objects...)
+
+		if notesGenerator, ok := generator.(manifests.NotesGenerator); ok {
+			notes, err := notesGenerator.GetNotes(generateCtx, releaseComponent.Namespace, releaseComponent.Name, types.UnstructurableMap(allValues))
+			if err != nil {
+				return nil, "", err
+			}
+			if notes != "" {
+				allNotes = append(allNotes, notes)
+			}
+		}
 	}
 
-	return allObjects, nil
+	return allObjects, strings.Join(allNotes, "\n"), nil
 }
@@ -9,8 +9,17 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
+func isRemoteChartSource(source string) bool {
+	return strings.HasPrefix(source, "oci://") || strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+func isChartArchive(path string) bool {
+	return strings.HasSuffix(path, ".tgz") || strings.HasSuffix(path, ".tar.gz")
+}
+
 func copyFile(src, dst string) (int64, error) {
 	sourceFileStat, err := os.Stat(src)
 	if err != nil {
@@ -25,6 +25,7 @@ const (
 	dataKeyRevision          = "revision"
 	dataKeyInventory         = "inventory"
 	dataKeyState             = "state"
+	dataKeyNotes             = "notes"
 )
 
 type Release struct {
@@ -36,6 +37,7 @@ type Release struct {
 	Revision          int64
 	Inventory         []*reconciler.InventoryItem
 	State             component.State
+	Notes             string
 }
 
 func NewRelease(namespace string, name string) *Release {
@@ -114,6 +116,12 @@ func (r *Release) importData() error {
 		r.State = ""
 	}
 
+	if notesData, ok := r.configMap.Data[dataKeyNotes]; ok {
+		r.Notes = notesData
+	} else {
+		r.Notes = ""
+	}
+
 	return nil
 }
 
@@ -154,5 +162,11 @@ func (r *Release) exportData() error {
 		delete(r.configMap.Data, dataKeyState)
 	}
 
+	if r.Notes != "" {
+		r.configMap.Data[dataKeyNotes] = r.Notes
+	} else {
+		delete(r.configMap.Data, dataKeyNotes)
+	}
+
 	return nil
 }
@@ -26,24 +26,58 @@ func NewBackoff(limiter workqueue.RateLimiter) *Backoff {
 }
 
 func (b *Backoff) Next(item any, activity any) time.Duration {
+	return b.next(item, activity, 0)
+}
+
+func (b *Backoff) Forget(item any) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	if act, ok := b.activities[item]; ok && act != activity {
+	if act, ok := b.activities[item]; ok {
 		b.limiter.Forget([2]any{item, act})
 	}
 
-	b.activities[item] = activity
-	return b.limiter.When([2]any{item, activity})
+	delete(b.activities, item)
 }
 
-func (b *Backoff) Forget(item any) {
+// Return a view on the backoff which applies the given maximum delay (if positive); if the underlying rate limiter
+// implements MaxDelayRateLimiter, the maximum delay replaces the rate limiter's own one; otherwise, the delays returned
+// by the rate limiter are just capped at the given maximum delay.
+func (b *Backoff) WithMaxDelay(maxDelay time.Duration) *MaxDelayBackoff {
+	return &MaxDelayBackoff{
+		backoff:  b,
+		maxDelay: maxDelay,
+	}
+}
+
+func (b *Backoff) next(item any, activity any, maxDelay time.Duration) time.Duration {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	if act, ok := b.activities[item]; ok {
+	if act, ok := b.activities[item]; ok && act != activity {
 		b.limiter.Forget([2]any{item, act})
 	}
 
-	delete(b.activities, item)
+	b.activities[item] = activity
+	key := [2]any{item, activity}
+	if maxDelay <= 0 {
+		return b.limiter.When(key)
+	}
+	if limiter, ok := b.limiter.(MaxDelayRateLimiter); ok {
+		return limiter.WhenWithMaxDelay(key, maxDelay)
+	}
+	return min(b.limiter.When(key), maxDelay)
+}
+
+type MaxDelayBackoff struct {
+	backoff  *Backoff
+	maxDelay time.Duration
+}
+
+func (b *MaxDelayBackoff) Next(item any, activity any) time.Duration {
+	return b.backoff.next(item, activity, b.maxDelay)
+}
+
+func (b *MaxDelayBackoff) Forget(item any) {
+	b.backoff.Forget(item)
 }
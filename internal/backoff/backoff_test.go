@@ -37,4 +37,23 @@ var _ = Describe("testing: backoff.go", func() {
 		Expect(backoff.Next("item-2", "activity-1")).To(Equal(1 * time.Millisecond))
 	})
 
+	It("should replace the maximum delay of rate limiters supporting it", func() {
+		b := backoff.NewBackoff(backoff.NewDefaultRateLimiter(10 * time.Second))
+		for range 50 {
+			b.WithMaxDelay(time.Minute).Next("item-1", "activity-1")
+		}
+		Expect(b.WithMaxDelay(time.Minute).Next("item-1", "activity-1")).To(Equal(time.Minute))
+		Expect(b.Next("item-1", "activity-1")).To(Equal(10 * time.Second))
+	})
+
+	It("should cap the delays of other rate limiters at the maximum delay", func() {
+		b := backoff.NewBackoff(workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 1*time.Hour))
+		Expect(b.WithMaxDelay(3*time.Second).Next("item-1", "activity-1")).To(Equal(1 * time.Second))
+		Expect(b.WithMaxDelay(3*time.Second).Next("item-1", "activity-1")).To(Equal(2 * time.Second))
+		Expect(b.WithMaxDelay(3*time.Second).Next("item-1", "activity-1")).To(Equal(3 * time.Second))
+		Expect(b.WithMaxDelay(3*time.Second).Next("item-1", "activity-1")).To(Equal(3 * time.Second))
+		Expect(b.Next("item-1", "activity-1")).To(Equal(16 * time.Second))
+		b.WithMaxDelay(3 * time.Second).Forget("item-1")
+		Expect(b.Next("item-1", "activity-1")).To(Equal(1 * time.Second))
+	})
 })
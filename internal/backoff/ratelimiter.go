@@ -6,23 +6,76 @@ SPDX-License-Identifier: Apache-2.0
 package backoff
 
 import (
+	"sync"
 	"time"
 
 	"k8s.io/client-go/util/workqueue"
 )
 
+// MaxDelayRateLimiter is implemented by rate limiters which allow to override their maximum delay per call.
+type MaxDelayRateLimiter interface {
+	workqueue.RateLimiter
+	// Same as When(), but using the given maximum delay instead of the rate limiter's own one.
+	WhenWithMaxDelay(item any, maxDelay time.Duration) time.Duration
+}
+
 /*
 The default rate limiter does
 - 5 quick roundtrips (exponential, below 1s)
 - then 15 roundtrips at 1s
 - then 30 roundtrips at 2s
 - then roundtrips at maxDelay
+where all delays are capped at maxDelay.
 */
 
+type defaultRateLimiter struct {
+	mutex    sync.Mutex
+	failures map[any]int
+	maxDelay time.Duration
+}
+
+var _ MaxDelayRateLimiter = &defaultRateLimiter{}
+
 func NewDefaultRateLimiter(maxDelay time.Duration) workqueue.RateLimiter {
-	return workqueue.NewMaxOfRateLimiter(
-		workqueue.NewItemExponentialFailureRateLimiter(50*time.Millisecond, 1*time.Second),
-		workqueue.NewItemFastSlowRateLimiter(0, 2*time.Second, 20),
-		workqueue.NewItemFastSlowRateLimiter(0, maxDelay, 20+30),
-	)
+	return &defaultRateLimiter{
+		failures: make(map[any]int),
+		maxDelay: maxDelay,
+	}
+}
+
+func (r *defaultRateLimiter) When(item any) time.Duration {
+	return r.WhenWithMaxDelay(item, r.maxDelay)
+}
+
+func (r *defaultRateLimiter) WhenWithMaxDelay(item any, maxDelay time.Duration) time.Duration {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	failures := r.failures[item]
+	r.failures[item] = failures + 1
+
+	delay := maxDelay
+	switch {
+	case failures < 5:
+		delay = 50 * time.Millisecond << failures
+	case failures < 20:
+		delay = 1 * time.Second
+	case failures < 50:
+		delay = 2 * time.Second
+	}
+	return min(delay, maxDelay)
+}
+
+func (r *defaultRateLimiter) NumRequeues(item any) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.failures[item]
+}
+
+func (r *defaultRateLimiter) Forget(item any) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.failures, item)
 }
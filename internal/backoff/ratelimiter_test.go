@@ -32,4 +32,22 @@ var _ = Describe("testing: ratelimiter.go", func() {
 		}
 	})
 
+	It("should apply the maximum delay passed per call", func() {
+		ratelimiter := backoff.NewDefaultRateLimiter(5 * time.Second).(backoff.MaxDelayRateLimiter)
+		for i := range 100 {
+			switch {
+			case i < 5:
+				Expect(ratelimiter.WhenWithMaxDelay("test", 30*time.Second)).To(Equal(50 * (1 << i) * time.Millisecond))
+			case i < 20:
+				Expect(ratelimiter.WhenWithMaxDelay("test", 30*time.Second)).To(Equal(1 * time.Second))
+			case i < 50:
+				Expect(ratelimiter.WhenWithMaxDelay("test", 1500*time.Millisecond)).To(Equal(1500 * time.Millisecond))
+			default:
+				Expect(ratelimiter.WhenWithMaxDelay("test", 30*time.Second)).To(Equal(30 * time.Second))
+			}
+		}
+		Expect(ratelimiter.NumRequeues("test")).To(Equal(100))
+		ratelimiter.Forget("test")
+		Expect(ratelimiter.When("test")).To(Equal(50 * time.Millisecond))
+	})
 })
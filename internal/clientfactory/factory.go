@@ -30,13 +30,23 @@ type ClientFactory struct {
 	controllerName string
 	config         *rest.Config
 	validity       time.Duration
+	allowExec      bool
 	scheme         *runtime.Scheme
 	clients        map[string]*Client
+	// keys of the clients most recently retrieved by owners (see GetFor())
+	owners map[string]string
+}
+
+type ClientFactoryOptions struct {
+	// Duration after which clients are evicted if they were not retrieved; if zero, 15 minutes are assumed.
+	TTL time.Duration
+	// Whether kubeconfigs may use exec credential plugins; if false, retrieving a client for such a kubeconfig fails.
+	AllowExecCredentialPlugins bool
 }
 
 const validity = 15 * time.Minute
 
-func NewClientFactory(name string, controllerName string, config *rest.Config, schemeBuilders []types.SchemeBuilder) (*ClientFactory, error) {
+func NewClientFactory(name string, controllerName string, config *rest.Config, schemeBuilders []types.SchemeBuilder, options ClientFactoryOptions) (*ClientFactory, error) {
 	scheme := runtime.NewScheme()
 	// note: it would be sufficient to just add the corev1 scheme instead of the whole client-go scheme;
 	// but for convenience, we add all the api groups
@@ -55,13 +65,19 @@ func NewClientFactory(name string, controllerName string, config *rest.Config, s
 		}
 	}
 
+	if options.TTL == 0 {
+		options.TTL = validity
+	}
+
 	factory := &ClientFactory{
 		name:           name,
 		controllerName: controllerName,
 		config:         config,
-		validity:       validity,
+		validity:       options.TTL,
+		allowExec:      options.AllowExecCredentialPlugins,
 		scheme:         scheme,
 		clients:        make(map[string]*Client),
+		owners:         make(map[string]string),
 	}
 
 	go func() {
@@ -72,9 +88,8 @@ func NewClientFactory(name string, controllerName string, config *rest.Config, s
 			factory.mutex.Lock()
 			for key, clnt := range factory.clients {
 				if clnt.validUntil.Before(now) {
-					clnt.eventBroadcaster.Shutdown()
 					// TODO: add some (debug) log output when client is removed; unfortunately, we have no logger in here ...
-					delete(factory.clients, key)
+					factory.evict(key)
 				}
 			}
 			metrics.ActiveClients.WithLabelValues(factory.controllerName).Set(float64(len(factory.clients)))
@@ -85,7 +100,17 @@ func NewClientFactory(name string, controllerName string, config *rest.Config, s
 	return factory, nil
 }
 
+// Get a client for the given kubeconfig (or, if empty, for the factory's own rest config), impersonating the given user and groups (if any).
+// Clients are cached; a cached client is evicted if it was not retrieved within the factory's TTL.
 func (f *ClientFactory) Get(kubeConfig []byte, impersonationUser string, impersonationGroups []string) (*Client, error) {
+	return f.GetFor("", kubeConfig, impersonationUser, impersonationGroups)
+}
+
+// Get a client on behalf of the given owner (for example a component); same as Get(), but in addition, if the owner previously retrieved
+// a client for a different configuration (e.g. because the credentials in its kubeconfig were rotated), then that previous client is
+// evicted right away (unless it is still used by other owners), instead of waiting for the TTL to expire.
+// If owner is empty, this is equivalent to Get().
+func (f *ClientFactory) GetFor(owner string, kubeConfig []byte, impersonationUser string, impersonationGroups []string) (*Client, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -100,6 +125,15 @@ func (f *ClientFactory) Get(kubeConfig []byte, impersonationUser string, imperso
 		if err != nil {
 			return nil, err
 		}
+		if config.ExecProvider != nil {
+			if !f.allowExec {
+				return nil, fmt.Errorf("kubeconfig uses an exec credential plugin (%s), which is not allowed", config.ExecProvider.Command)
+			}
+			// note: the plugin runs non-interactively; client-go caches the returned credential until it expires
+			// (or until the api server rejects it), and then re-runs the plugin
+			config.ExecProvider.StdinUnavailable = true
+			config.ExecProvider.StdinUnavailableMessage = "exec credential plugins are run non-interactively"
+		}
 		keyData["kubeConfig"] = string(kubeConfig)
 	}
 
@@ -119,6 +153,16 @@ func (f *ClientFactory) Get(kubeConfig []byte, impersonationUser string, imperso
 
 	key := sha256sum(keyData)
 
+	if owner != "" {
+		if previousKey, ok := f.owners[owner]; ok && previousKey != key {
+			delete(f.owners, owner)
+			if !f.isOwned(previousKey) {
+				f.evict(previousKey)
+			}
+		}
+		f.owners[owner] = key
+	}
+
 	if clnt, ok := f.clients[key]; ok {
 		clnt.validUntil = time.Now().Add(f.validity)
 		return clnt, nil
@@ -138,6 +182,30 @@ func (f *ClientFactory) Get(kubeConfig []byte, impersonationUser string, imperso
 	return clnt, nil
 }
 
+// evict client with given key; must be called with the factory's mutex being held
+func (f *ClientFactory) evict(key string) {
+	if clnt, ok := f.clients[key]; ok {
+		clnt.eventBroadcaster.Shutdown()
+		delete(f.clients, key)
+	}
+	for owner, ownedKey := range f.owners {
+		if ownedKey == key {
+			delete(f.owners, owner)
+		}
+	}
+	metrics.ActiveClients.WithLabelValues(f.controllerName).Set(float64(len(f.clients)))
+}
+
+// check whether client with given key was most recently retrieved by any owner; must be called with the factory's mutex being held
+func (f *ClientFactory) isOwned(key string) bool {
+	for _, ownedKey := range f.owners {
+		if ownedKey == key {
+			return true
+		}
+	}
+	return false
+}
+
 // TODO: this could be repplaced by util.CalculateDigest()
 func sha256sum(data any) string {
 	dataAsJson, err := json.Marshal(data)
@@ -33,7 +33,7 @@ var _ = Describe("testing: factory.go", func() {
 
 		schemeBuilder := runtime.NewSchemeBuilder(cstestingv1alpha1.AddToScheme)
 
-		factory, err = NewClientFactory("test-controller", "testing", env.Config(), []types.SchemeBuilder{&schemeBuilder})
+		factory, err = NewClientFactory("test-controller", "testing", env.Config(), []types.SchemeBuilder{&schemeBuilder}, ClientFactoryOptions{})
 		Expect(err).NotTo(HaveOccurred())
 
 		namespace, err = env.CreateNamespace()
@@ -179,4 +179,55 @@ var _ = Describe("testing: factory.go", func() {
 		}, 15*time.Second, 1*time.Second).Should(Equal(0))
 	})
 
+	It("should evict the previous client of an owner if its configuration changes", func() {
+		kubeConfig := []byte(env.KubeConfig())
+		rotatedKubeConfig := append([]byte(env.KubeConfig()), []byte("\n# rotated\n")...)
+
+		clnt, err := factory.GetFor("owner1", kubeConfig, "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		clnt2, err := factory.GetFor("owner2", kubeConfig, "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clnt2).To(BeIdenticalTo(clnt))
+		Expect(factory.clients).To(HaveLen(1))
+
+		_, err = factory.GetFor("owner1", rotatedKubeConfig, "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(factory.clients).To(HaveLen(2))
+
+		clnt, err = factory.GetFor("owner2", rotatedKubeConfig, "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(factory.clients).To(HaveLen(1))
+
+		err = clnt.Get(context.Background(), apitypes.NamespacedName{Name: "kube-system"}, &corev1.Namespace{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject exec credential plugins unless allowed", func() {
+		kubeConfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://localhost:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: get-token
+`)
+		_, err := factory.Get(kubeConfig, "", nil)
+		Expect(err).To(MatchError(ContainSubstring("exec credential plugin")))
+
+		factory.allowExec = true
+		_, err = factory.Get(kubeConfig, "", nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
 })
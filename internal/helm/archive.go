@@ -0,0 +1,76 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"testing/fstest"
+)
+
+// Load a packaged chart (that is, a gzipped tar archive, containing the chart files in a single top-level directory),
+// and return an in-memory filesystem, containing the chart under dir/<top-level directory>, along with the path of the chart.
+// The chart is mounted below dir (instead of the root of the returned filesystem), such that template names
+// are the same as if the archive had been extracted into dir.
+func loadChartArchive(raw []byte, dir string) (fs.FS, string, error) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading chart archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	fsys := fstest.MapFS{}
+	chartDir := ""
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, "", fmt.Errorf("error reading chart archive: %w", err)
+		}
+		switch header.Typeflag {
+		case tar.TypeReg, tar.TypeDir:
+		default:
+			// note: as helm does it, other entries (such as symbolic links, or pax headers) are ignored
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if !fs.ValidPath(name) || name == "." {
+			return nil, "", fmt.Errorf("invalid path in chart archive: %s", header.Name)
+		}
+		topDir, _, ok := strings.Cut(name, "/")
+		if !ok && header.Typeflag == tar.TypeReg {
+			return nil, "", fmt.Errorf("invalid chart archive: file %s not contained in a top-level directory", name)
+		}
+		if chartDir == "" {
+			chartDir = topDir
+		} else if topDir != chartDir {
+			return nil, "", fmt.Errorf("invalid chart archive: must contain exactly one top-level directory")
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		data, err := readLimited(tarReader, maxChartArchiveSize)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading %s from chart archive: %w", name, err)
+		}
+		fsys[path.Join(dir, name)] = &fstest.MapFile{Data: data, Mode: 0644}
+	}
+	if chartDir == "" {
+		return nil, "", fmt.Errorf("invalid chart archive: archive is empty")
+	}
+
+	return fsys, path.Join(dir, chartDir), nil
+}
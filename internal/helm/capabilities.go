@@ -6,25 +6,42 @@ SPDX-License-Identifier: Apache-2.0
 package helm
 
 import (
+	"fmt"
+	"path"
+	"runtime"
+
 	"github.com/sap/go-generics/slices"
 
 	"k8s.io/client-go/discovery"
 )
 
+// Version of helm which is emulated by this package; exposed through the .Capabilities.HelmVersion builtin.
+// Note: the expected manifests of the conformance tests are rendered with exactly this version (see testdata/build_conformance).
+const helmVersion = "v3.17.3"
+
+// Get capabilities (kubernetes version and available api versions) from the given discovery client, the same way as helm does it.
+// That is, API versions contain all served group versions (such as apps/v1), as well as all served kinds, qualified by
+// their group version (such as apps/v1/Deployment). As with helm, failures discovering single api groups (caused for example by
+// unavailable aggregated api services) are tolerated; the according group versions are then missing in the result.
 func GetCapabilities(discoveryClient discovery.DiscoveryInterface) (*Capabilities, error) {
 	kubeVersion, err := discoveryClient.ServerVersion()
 	if err != nil {
 		return nil, err
 	}
 	var apiVersions []string
-	_, apiResourceLists, err := discoveryClient.ServerGroupsAndResources()
-	if err != nil {
-		return nil, err
+	apiGroups, apiResourceLists, err := discoveryClient.ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("error discovering api versions: %w", err)
+	}
+	for _, apiGroup := range apiGroups {
+		for _, groupVersion := range apiGroup.Versions {
+			apiVersions = append(apiVersions, groupVersion.GroupVersion)
+		}
 	}
 	for _, apiResourceList := range apiResourceLists {
 		apiVersions = append(apiVersions, apiResourceList.GroupVersion)
 		for _, apiResource := range apiResourceList.APIResources {
-			apiVersions = append(apiVersions, apiResourceList.GroupVersion+"/"+apiResource.Kind)
+			apiVersions = append(apiVersions, path.Join(apiResourceList.GroupVersion, apiResource.Kind))
 		}
 	}
 	capabilities := &Capabilities{
@@ -35,6 +52,10 @@ func GetCapabilities(discoveryClient discovery.DiscoveryInterface) (*Capabilitie
 			GitVersion: kubeVersion.GitVersion,
 		},
 		APIVersions: slices.Uniq(apiVersions),
+		HelmVersion: HelmVersion{
+			Version:   helmVersion,
+			GoVersion: runtime.Version(),
+		},
 	}
 	return capabilities, nil
 }
@@ -26,10 +26,12 @@ import (
 	kyaml "sigs.k8s.io/yaml"
 
 	"github.com/sap/component-operator-runtime/internal/fileutils"
+	"github.com/sap/component-operator-runtime/internal/jsonschema"
 	"github.com/sap/component-operator-runtime/internal/templatex"
-	"github.com/sap/component-operator-runtime/pkg/manifests"
 )
 
+const notesFile = "NOTES.txt"
+
 // TODO: give errors more context
 // TODO: double-check symlink handling
 
@@ -41,26 +43,49 @@ type RenderContext struct {
 	Values          map[string]any
 }
 
+// ChartOptions allows to tweak the parsing of charts.
+type ChartOptions struct {
+	// If defined, custom template functions, which are merged into the functions provided by templatex.FuncMap() (see templatex.MergeFuncMap());
+	// custom functions are available in the templates of the chart and all its subcharts
+	FuncMap template.FuncMap
+}
+
 type Chart struct {
 	parent    *Chart
+	path      string
 	subCharts map[string]*Chart
 	metadata  *ChartMetadata
 	values    map[string]any
+	schema    *jsonschema.Schema
 	crds      [][]byte
 	t0        *template.Template
 	templates []string
+	notes     string
 	files     Files
+	ignore    *ignoreRules
+	funcs     template.FuncMap
+	// whether the chart explicitly declares dependencies (in Chart.yaml or requirements.yaml)
+	hasDependencies bool
 }
 
 func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
-	chart := &Chart{
-		subCharts: make(map[string]*Chart),
-	}
+	funcs := templatex.FuncMap()
 	if parent != nil {
-		chart.parent = parent
-		chart.t0 = parent.t0
+		funcs = parent.funcs
 	}
+	return parseChartPath(fsys, chartPath, parent, funcs)
+}
+
+// Parse a (top-level) chart, as ParseChart() does it, using the given options.
+func ParseChartWithOptions(fsys fs.FS, chartPath string, options ChartOptions) (*Chart, error) {
+	funcs, err := templatex.MergeFuncMap(options.FuncMap)
+	if err != nil {
+		return nil, err
+	}
+	return parseChartPath(fsys, chartPath, nil, funcs)
+}
 
+func parseChartPath(fsys fs.FS, chartPath string, parent *Chart, funcs template.FuncMap) (*Chart, error) {
 	if fsys == nil {
 		fsys = os.DirFS("/")
 		absoluteChartPath, err := filepath.Abs(chartPath)
@@ -73,9 +98,58 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 	}
 	chartPath = filepath.Clean(chartPath)
 
-	// TODO: we should filter out according to .helmignore
+	if info, err := fs.Stat(fsys, chartPath); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return parseChartArchive(fsys, chartPath, parent, funcs)
+	}
+
+	// note: as helm does it, the .helmignore file of the top-level chart is evaluated for the whole directory tree (including subcharts),
+	// whereas .helmignore files of subcharts are not considered
+	var ignore *ignoreRules
+	if parent != nil {
+		ignore = parent.ignore
+	} else {
+		helmIgnoreRaw, err := fs.ReadFile(fsys, filepath.Clean(chartPath+"/"+helmIgnoreFile))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		ignore, err = parseIgnoreRules(chartPath, helmIgnoreRaw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parseChart(fsys, chartPath, parent, ignore, funcs)
+}
 
-	chartRaw, err := fs.ReadFile(fsys, filepath.Clean(chartPath+"/Chart.yaml"))
+// Parse a packaged chart (that is, a gzipped tar archive); the archive is loaded into memory and mounted next to the archive file.
+// Note that, as with helm, .helmignore files are not evaluated for packaged charts (because they were applied when the archive was built).
+func parseChartArchive(fsys fs.FS, chartPath string, parent *Chart, funcs template.FuncMap) (*Chart, error) {
+	raw, err := fs.ReadFile(fsys, chartPath)
+	if err != nil {
+		return nil, err
+	}
+	archiveFsys, archiveChartPath, err := loadChartArchive(raw, filepath.Dir(chartPath))
+	if err != nil {
+		return nil, fmt.Errorf("error loading chart %s: %w", chartPath, err)
+	}
+	return parseChart(archiveFsys, archiveChartPath, parent, nil, funcs)
+}
+
+func parseChart(fsys fs.FS, chartPath string, parent *Chart, ignore *ignoreRules, funcs template.FuncMap) (*Chart, error) {
+	chart := &Chart{
+		path:      chartPath,
+		subCharts: make(map[string]*Chart),
+		ignore:    ignore,
+		funcs:     funcs,
+	}
+	if parent != nil {
+		chart.parent = parent
+		chart.t0 = parent.t0
+	}
+
+	chartRaw, err := chart.readFile(fsys, filepath.Clean(chartPath+"/Chart.yaml"))
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +158,7 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 		return nil, err
 	}
 	if chart.metadata.Dependencies == nil {
-		requirementsRaw, err := fs.ReadFile(fsys, filepath.Clean(chartPath+"/requirements.yaml"))
+		requirementsRaw, err := chart.readFile(fsys, filepath.Clean(chartPath+"/requirements.yaml"))
 		if err == nil {
 			requirements := struct {
 				Dependencies []ChartDependency `json:"dependencies,omitempty"`
@@ -97,27 +171,24 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 			return nil, err
 		}
 	}
+	chart.hasDependencies = chart.metadata.Dependencies != nil
 	if chart.metadata.Type == "" {
 		chart.metadata.Type = ChartTypeApplication
 	}
-	if chart.parent != nil && chart.parent.metadata.Type == ChartTypeLibrary && chart.metadata.Type == ChartTypeApplication {
-		return nil, fmt.Errorf("library chart cannot have application subchart")
-	}
-
 	if chart.metadata.Type == ChartTypeApplication {
-		crds, err := fileutils.Find(fsys, filepath.Clean(chartPath+"/crds"), "*.yaml", fileutils.FileTypeRegular, 0)
+		crds, err := chart.find(fsys, filepath.Clean(chartPath+"/crds"), "*.yaml", fileutils.FileTypeRegular, 0)
 		if err != nil {
 			return nil, err
 		}
 		for _, crd := range crds {
-			raw, err := fs.ReadFile(fsys, crd)
+			raw, err := chart.readFile(fsys, crd)
 			if err != nil {
 				return nil, err
 			}
 			chart.crds = append(chart.crds, raw)
 		}
 
-		manifests, err := fileutils.Find(fsys, filepath.Clean(chartPath+"/templates"), "[^_]*.yaml", fileutils.FileTypeRegular, 0)
+		manifests, err := chart.find(fsys, filepath.Clean(chartPath+"/templates"), "[^_]*.yaml", fileutils.FileTypeRegular, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -126,9 +197,23 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 				return nil, err
 			}
 		}
+
+		// note: as helm does it by default, only the notes of the top-level chart are considered
+		if chart.parent == nil {
+			notes, err := chart.find(fsys, filepath.Clean(chartPath+"/templates"), notesFile, fileutils.FileTypeRegular, 1)
+			if err != nil {
+				return nil, err
+			}
+			for _, note := range notes {
+				if err := chart.parseTemplate(fsys, note, true); err != nil {
+					return nil, err
+				}
+				chart.notes = note
+			}
+		}
 	}
 
-	includes, err := fileutils.Find(fsys, filepath.Clean(chartPath+"/templates"), "_*", fileutils.FileTypeRegular, 0)
+	includes, err := chart.find(fsys, filepath.Clean(chartPath+"/templates"), "_*", fileutils.FileTypeRegular, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -139,12 +224,12 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 	}
 
 	chart.files = Files{}
-	files, err := fileutils.Find(fsys, filepath.Clean(chartPath), "", fileutils.FileTypeRegular, 0)
+	files, err := chart.find(fsys, filepath.Clean(chartPath), "", fileutils.FileTypeRegular, 0)
 	if err != nil {
 		return nil, err
 	}
 	for _, file := range files {
-		raw, err := fs.ReadFile(fsys, file)
+		raw, err := chart.readFile(fsys, file)
 		if err != nil {
 			return nil, err
 		}
@@ -156,7 +241,7 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 		chart.files.add(name, raw)
 	}
 
-	valuesRaw, err := fs.ReadFile(fsys, filepath.Clean(chartPath+"/values.yaml"))
+	valuesRaw, err := chart.readFile(fsys, filepath.Clean(chartPath+"/values.yaml"))
 	if err == nil {
 		chart.values = make(map[string]any)
 		if err := kyaml.Unmarshal(valuesRaw, &chart.values); err != nil {
@@ -168,16 +253,44 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 		return nil, err
 	}
 
-	subChartPaths, err := fileutils.Find(fsys, filepath.Clean(chartPath+"/charts"), "*", fileutils.FileTypeDir, 1)
+	schemaRaw, err := chart.readFile(fsys, filepath.Clean(chartPath+"/values.schema.json"))
+	if err == nil {
+		chart.schema, err = jsonschema.Compile(schemaRaw)
+		if err != nil {
+			return nil, fmt.Errorf("error compiling values.schema.json of chart %s: %w", chart.metadata.Name, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	subChartPaths, err := chart.find(fsys, filepath.Clean(chartPath+"/charts"), "*", fileutils.FileTypeDir, 1)
+	if err != nil {
+		return nil, err
+	}
+	subChartArchivePaths, err := chart.find(fsys, filepath.Clean(chartPath+"/charts"), "*.tgz", fileutils.FileTypeRegular, 1)
 	if err != nil {
 		return nil, err
 	}
-	for _, subChartPath := range subChartPaths {
-		subChart, err := ParseChart(fsys, subChartPath, chart)
+	for _, subChartPath := range append(subChartPaths, subChartArchivePaths...) {
+		// note: as helm does it, entries starting with an underscore or a dot are skipped
+		if strings.IndexAny(filepath.Base(subChartPath), "_.") == 0 {
+			continue
+		}
+		var subChart *Chart
+		if strings.HasSuffix(subChartPath, ".tgz") {
+			subChart, err = parseChartArchive(fsys, subChartPath, chart, chart.funcs)
+		} else {
+			subChart, err = parseChart(fsys, subChartPath, chart, chart.ignore, chart.funcs)
+		}
 		if err != nil {
 			return nil, err
 		}
-		subChartName := filepath.Base(subChartPath)
+		// note: as helm does it, subcharts are identified by the name in their Chart.yaml (and not by the directory or file name;
+		// the latter usually contains the version in case of archived subcharts)
+		subChartName := subChart.metadata.Name
+		if _, ok := chart.subCharts[subChartName]; ok {
+			return nil, fmt.Errorf("duplicate subchart %s in chart %s", subChartName, chart.metadata.Name)
+		}
 		chart.subCharts[subChartName] = subChart
 
 		if slices.None(chart.metadata.Dependencies, func(dep ChartDependency) bool { return dep.Name == subChartName }) {
@@ -190,23 +303,12 @@ func ParseChart(fsys fs.FS, chartPath string, parent *Chart) (*Chart, error) {
 		if dep.Alias == "" {
 			dep.Alias = dep.Name
 		}
-		subChart, ok := chart.subCharts[dep.Name]
-		if !ok {
+		if _, ok := chart.subCharts[dep.Name]; !ok {
 			return nil, fmt.Errorf("dependent chart %s not found", dep.Name)
 		}
 
 		// TODO: validate dependency version against actual version in subchart's Chart.yaml
 		// TODO: also consider Chart.lock?
-
-		for _, val := range dep.ImportValues {
-			if v, _, ok := digMap(subChart.values, val.Child); ok {
-				for k, v := range v {
-					if err := undig(chart.values, v, val.Parent, k); err != nil {
-						return nil, err
-					}
-				}
-			}
-		}
 	}
 
 	return chart, nil
@@ -218,21 +320,97 @@ func (c *Chart) Render(context RenderContext) ([]client.Object, error) {
 		return nil, err
 	}
 
-	var t0 *template.Template
-	if c.t0 != nil {
-		t0, err = c.t0.Clone()
-		if err != nil {
-			return nil, err
-		}
-		t0.Option("missingkey=zero").
-			Funcs(templatex.FuncMapForTemplate(t0)).
-			Funcs(templatex.FuncMapForLocalClient(context.LocalClient)).
-			Funcs(templatex.FuncMapForClient(context.Client))
+	t0, err := c.cloneTemplates(context)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := c.newScope(capabilities, context.Release, context.Values)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.render("", t0, capabilities, context.Release, context.Values)
+	return scope.render(t0)
 }
 
+// Render the chart's templates/NOTES.txt; returns an empty string if the chart has no notes.
+// As with helm, notes of subcharts are not rendered.
+func (c *Chart) RenderNotes(context RenderContext) (string, error) {
+	if c.notes == "" {
+		return "", nil
+	}
+
+	capabilities, err := GetCapabilities(context.DiscoveryClient)
+	if err != nil {
+		return "", err
+	}
+
+	t0, err := c.cloneTemplates(context)
+	if err != nil {
+		return "", err
+	}
+
+	scope, err := c.newScope(capabilities, context.Release, context.Values)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := scope.execute(t0, c.notes, &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// Render the chart's templates, and, in the same pass, the chart's templates/NOTES.txt (as RenderNotes() does).
+func (c *Chart) RenderWithNotes(context RenderContext) ([]client.Object, string, error) {
+	capabilities, err := GetCapabilities(context.DiscoveryClient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	t0, err := c.cloneTemplates(context)
+	if err != nil {
+		return nil, "", err
+	}
+
+	scope, err := c.newScope(capabilities, context.Release, context.Values)
+	if err != nil {
+		return nil, "", err
+	}
+
+	objects, err := scope.render(t0)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if c.notes == "" {
+		return objects, "", nil
+	}
+
+	var buf bytes.Buffer
+	if err := scope.execute(t0, c.notes, &buf); err != nil {
+		return nil, "", err
+	}
+
+	return objects, buf.String(), nil
+}
+
+func (c *Chart) cloneTemplates(context RenderContext) (*template.Template, error) {
+	if c.t0 == nil {
+		return nil, nil
+	}
+	t0, err := c.t0.Clone()
+	if err != nil {
+		return nil, err
+	}
+	t0.Option("missingkey=zero").
+		Funcs(templatex.FuncMapForTemplate(t0)).
+		Funcs(templatex.FuncMapForLocalClient(context.LocalClient)).
+		Funcs(templatex.FuncMapForClient(context.Client))
+	return t0, nil
+}
 func (c *Chart) parseTemplate(fsys fs.FS, path string, isInclude bool) error {
 	var t *template.Template
 
@@ -247,7 +425,7 @@ func (c *Chart) parseTemplate(fsys fs.FS, path string, isInclude bool) error {
 		c.t0 = template.New(path)
 		c.t0.Option("missingkey=zero").
 			Funcs(sprig.TxtFuncMap()).
-			Funcs(templatex.FuncMap()).
+			Funcs(c.funcs).
 			Funcs(templatex.FuncMapForTemplate(nil)).
 			Funcs(templatex.FuncMapForLocalClient(nil)).
 			Funcs(templatex.FuncMapForClient(nil))
@@ -266,101 +444,239 @@ func (c *Chart) parseTemplate(fsys fs.FS, path string, isInclude bool) error {
 	return nil
 }
 
-func (c *Chart) render(name string, t0 *template.Template, capabilities *Capabilities, release *Release, values map[string]any) ([]client.Object, error) {
-	var objects []client.Object
+// Read a file of the chart, respecting .helmignore; ignored files are reported as not existing.
+func (c *Chart) readFile(fsys fs.FS, path string) ([]byte, error) {
+	if c.ignore.ignored(path, false) {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	return fs.ReadFile(fsys, path)
+}
 
-	metadata := c.metadata.DeepCopy()
-	if name != "" {
-		metadata.Name = name
+// Find files of the chart (see fileutils.Find()), respecting .helmignore.
+func (c *Chart) find(fsys fs.FS, dir string, namePattern string, fileType uint, maxDepth uint) ([]string, error) {
+	paths, err := fileutils.Find(fsys, dir, namePattern, fileType, maxDepth)
+	if err != nil {
+		return nil, err
 	}
-	capabilities = capabilities.DeepCopy()
-	release = release.DeepCopy()
-	values = manifests.MergeMaps(c.values, values)
+	return slices.Select(paths, func(path string) bool { return !c.ignore.ignored(path, fileType == fileutils.FileTypeDir) }), nil
+}
 
-	data := make(map[string]any)
-	data["Chart"] = metadata
-	data["Capabilities"] = capabilities
-	data["Release"] = release
-	data["Values"] = values
-	data["Files"] = c.files
+// A scope is an enabled chart (or subchart) within the chart tree, along with the builtin objects passed to its templates.
+type scope struct {
+	chart *Chart
+	// name of the chart within the chart tree (that is, the alias, in case of aliased dependencies)
+	name string
+	// path of the chart within the chart tree, as used by helm in template names (such as mychart/charts/mysubchart)
+	path string
+	// default values of the chart, including the values imported from its subcharts
+	defaults  map[string]any
+	data      map[string]any
+	subScopes []*scope
+}
+
+// Create the scope for this chart (and recursively for all enabled subcharts), emulating the steps performed by helm when
+// installing a chart, that is:
+// 1. the passed values are coalesced with the default values of the whole chart tree; tags and conditions of dependencies are evaluated
+// against the result (see processDependencyEnabled() in helm.sh/helm/v3/pkg/chartutil),
+// 2. bottom-up, values are imported from the enabled subcharts into the default values of their parents (see processImportValues()),
+// 3. the passed values are coalesced with the default values (including the imported ones) of the enabled charts (see CoalesceValues()),
+// and each chart sees the according section of the result as Values builtin.
+func (c *Chart) newScope(capabilities *Capabilities, release *Release, values map[string]any) (*scope, error) {
+	if c.metadata.Type == ChartTypeLibrary {
+		return nil, fmt.Errorf("library charts are not installable")
+	}
 
+	cvals, err := coalesceValues(values, c.newValuesNode(c.metadata.Name), false)
+	if err != nil {
+		return nil, err
+	}
+	tags, _, _ := getMap(cvals, "tags")
+	s := c.newScopeTree(c.metadata.Name, c.metadata.Name, cvals, tags, nil)
+
+	if err := s.importValues(); err != nil {
+		return nil, err
+	}
+
+	values, err = coalesceValues(values, s.newValuesNode(), false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.populate(capabilities, release, values); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Build the values tree of this chart and all its (enabled or not) dependencies, using the default values as found in the chart.
+func (c *Chart) newValuesNode(name string) *valuesNode {
+	node := &valuesNode{
+		name:     name,
+		defaults: c.values,
+	}
 	for _, dep := range c.metadata.Dependencies {
-		enabled := true
-
-		// evaluate tags
-		// if there is no matching tag, the dependency will be considered enabled
-		// otherwise if any of the tags is true, the dependency will be enabled
-		// otherwise if any of the tags is false, the dependency will be disabled
-		haveMatchingTag := false
-		for _, tag := range dep.Tags {
-			if v, exists, ok := digBool(values, "tags", tag); ok {
-				if v {
-					enabled = true
-				} else if !haveMatchingTag {
-					enabled = false
-				}
-				haveMatchingTag = true
-			} else if exists {
-				return nil, fmt.Errorf("tag references a non-boolean value")
-			}
+		node.children = append(node.children, c.subCharts[dep.Name].newValuesNode(dep.Alias))
+	}
+	return node
+}
+
+// Create the scope tree for this chart and all enabled dependencies; cvals are the coalesced values of the whole chart tree,
+// prefix is the path of the chart's section within cvals (as used to evaluate conditions), tags are the (top-level) tags.
+func (c *Chart) newScopeTree(name string, path string, cvals map[string]any, tags map[string]any, prefix []string) *scope {
+	s := &scope{
+		chart: c,
+		name:  name,
+		path:  path,
+	}
+
+	for _, dep := range c.metadata.Dependencies {
+		if !isDependencyEnabled(dep, cvals, tags, prefix) {
+			continue
 		}
+		subPrefix := append(append([]string{}, prefix...), dep.Alias)
+		s.subScopes = append(s.subScopes, c.subCharts[dep.Name].newScopeTree(dep.Alias, path+"/charts/"+dep.Alias, cvals, tags, subPrefix))
+	}
 
-		// evaluate condition
-		// if a matching condition path is found, its value will win over defaults and tag-based settings
-		// and the first matching condition path will terminate the evaluation of further condition paths
-		if dep.Condition != "" {
-			for _, cond := range strings.Split(dep.Condition, ",") {
-				if v, exists, ok := digBool(values, cond); ok {
-					enabled = v
-					break
-				} else if exists {
-					return nil, fmt.Errorf("condition references a non-boolean value")
-				}
+	return s
+}
+
+// Evaluate tags and conditions of the given dependency, as helm does it (see processDependencyTags() and processDependencyConditions()
+// in helm.sh/helm/v3/pkg/chartutil); in particular, as with helm, non-boolean values are ignored.
+func isDependencyEnabled(dep ChartDependency, cvals map[string]any, tags map[string]any, prefix []string) bool {
+	enabled := true
+
+	// evaluate tags
+	// if there is no matching tag, the dependency will be considered enabled
+	// otherwise if any of the tags is true, the dependency will be enabled
+	// otherwise if any of the tags is false, the dependency will be disabled
+	hasTrue, hasFalse := false, false
+	for _, tag := range dep.Tags {
+		if v, ok := tags[tag].(bool); ok {
+			if v {
+				hasTrue = true
+			} else {
+				hasFalse = true
 			}
 		}
+	}
+	if hasFalse && !hasTrue {
+		enabled = false
+	}
 
-		if !enabled {
-			continue
+	// evaluate condition
+	// if a matching condition path is found, its value will win over defaults and tag-based settings
+	// and the first matching condition path will terminate the evaluation of further condition paths
+	if dep.Condition != "" {
+		for _, cond := range strings.Split(strings.TrimSpace(dep.Condition), ",") {
+			if v, _, ok := digBool(cvals, append(append([]string{}, prefix...), cond)...); ok {
+				enabled = v
+				break
+			}
 		}
+	}
 
-		depChart := c.subCharts[dep.Name]
-		depValues, _, _ := getMap(values, dep.Alias)
-		if depValues == nil {
-			depValues = make(map[string]any)
+	return enabled
+}
+
+// Compute the default values of this scope (and recursively of all subscopes), importing values from enabled subcharts, as specified by the
+// import-values of the according dependencies; as with helm, values of the importing chart take precedence over imported values, and earlier
+// imports take precedence over later ones.
+func (s *scope) importValues() error {
+	for _, subScope := range s.subScopes {
+		if err := subScope.importValues(); err != nil {
+			return err
 		}
+	}
 
-		if v, exists, ok := getMap(values, "global"); ok {
-			if w, exists, ok := getMap(depValues, "global"); ok {
-				depValues["global"] = manifests.MergeMaps(w, v)
-			} else if exists {
-				return nil, fmt.Errorf("values key 'global' exists but is not a map")
-			} else {
-				depValues["global"] = v
+	// note: as helm does it, charts not declaring dependencies keep their default values as they are
+	if !s.chart.hasDependencies {
+		s.defaults = s.chart.values
+		return nil
+	}
+
+	node := &valuesNode{
+		name:     s.name,
+		defaults: s.chart.values,
+	}
+	for _, subScope := range s.subScopes {
+		node.children = append(node.children, subScope.newValuesNode())
+	}
+	cvals, err := coalesceValues(nil, node, true)
+	if err != nil {
+		return err
+	}
+
+	// note: as with helm, imported tables are not copied (see importTables()); therefore cvals might be modified while collecting the imports
+	imports := make(map[string]any)
+	for _, dep := range s.chart.metadata.Dependencies {
+		for _, val := range dep.ImportValues {
+			if v, _, ok := digMap(cvals, dep.Alias, val.Child); ok {
+				importTables(imports, pathToMap(val.Parent, v))
 			}
-		} else if exists {
-			return nil, fmt.Errorf("values key 'global' exists but is not a map")
 		}
+	}
+	s.defaults = coalesceTables(cvals, imports, true)
 
-		if v, exists, ok := getMap(values, "tags"); ok {
-			if w, exists, ok := getMap(depValues, "tags"); ok {
-				depValues["tags"] = manifests.MergeMaps(w, v)
-			} else if exists {
-				return nil, fmt.Errorf("values key 'tags' exists but is not a map")
-			} else {
-				depValues["tags"] = v
-			}
-		} else if exists {
-			return nil, fmt.Errorf("values key 'tags' exists but is not a map")
+	return nil
+}
+
+// Build the values tree of this scope and all subscopes, using the default values computed by importValues().
+func (s *scope) newValuesNode() *valuesNode {
+	node := &valuesNode{
+		name:     s.name,
+		defaults: s.defaults,
+	}
+	for _, subScope := range s.subScopes {
+		node.children = append(node.children, subScope.newValuesNode())
+	}
+	return node
+}
+
+// Populate the builtin objects of this scope (and recursively of all subscopes); values are the final (coalesced) values of this scope's chart.
+func (s *scope) populate(capabilities *Capabilities, release *Release, values map[string]any) error {
+	metadata := s.chart.metadata.DeepCopy()
+	metadata.Name = s.name
+	if s.chart.schema != nil {
+		if err := s.chart.schema.Validate(values); err != nil {
+			return fmt.Errorf("values of chart %s do not match values.schema.json: %w", metadata.Name, err)
+		}
+	}
+
+	subCharts := make(map[string]any)
+	for _, subScope := range s.subScopes {
+		// note: coalesceValues() guarantees that sections of subcharts exist, and are maps
+		if err := subScope.populate(capabilities, release, values[subScope.name].(map[string]any)); err != nil {
+			return err
 		}
+		// note: as with helm, the builtin objects of (enabled) subcharts are accessible through the Subcharts builtin, keyed by the (aliased) name
+		subCharts[subScope.name] = subScope.data
+	}
+
+	s.data = map[string]any{
+		"Chart":        metadata,
+		"Capabilities": capabilities.DeepCopy(),
+		"Release":      release.DeepCopy(),
+		"Values":       values,
+		"Files":        s.chart.files,
+		"Subcharts":    subCharts,
+	}
+
+	return nil
+}
 
-		depObjects, err := depChart.render(dep.Alias, t0, capabilities, release, depValues)
+// Render the templates (and CRDs) of this scope and all its subscopes.
+func (s *scope) render(t0 *template.Template) ([]client.Object, error) {
+	var objects []client.Object
+
+	for _, subScope := range s.subScopes {
+		subObjects, err := subScope.render(t0)
 		if err != nil {
 			return nil, err
 		}
-		objects = append(objects, depObjects...)
+		objects = append(objects, subObjects...)
 	}
 
-	for _, f := range c.crds {
+	for _, f := range s.chart.crds {
 		decoder := utilyaml.NewYAMLToJSONDecoder(bytes.NewBuffer(f))
 		for {
 			object := &unstructured.Unstructured{}
@@ -378,24 +694,9 @@ func (c *Chart) render(name string, t0 *template.Template, capabilities *Capabil
 	}
 
 	if t0 != nil {
-		for _, name := range c.templates {
-			data["Template"] = &Template{
-				// TODO: review (may be incorrect if templates dir contains a subfolder called templates)
-				Name: name,
-				BasePath: func(path string) string {
-					for path != "." && path != "/" {
-						path = filepath.Dir(path)
-						if filepath.Base(path) == "templates" {
-							return path
-						}
-					}
-					// note: this panic is ok because the way templates were selected ensures that they reside under the 'templates' directory
-					panic("this cannot happen")
-				}(name),
-			}
-
+		for _, name := range s.chart.templates {
 			var buf bytes.Buffer
-			if err := t0.ExecuteTemplate(&buf, name, data); err != nil {
+			if err := s.execute(t0, name, &buf); err != nil {
 				return nil, err
 			}
 
@@ -418,3 +719,24 @@ func (c *Chart) render(name string, t0 *template.Template, capabilities *Capabil
 
 	return objects, nil
 }
+
+// Execute the given template (identified by its path in the filesystem containing the chart) of this scope's chart;
+// the Template builtin is populated the same way as helm does it, that is, relative to the root of the chart tree.
+func (s *scope) execute(t0 *template.Template, name string, w io.Writer) error {
+	relativeName, err := filepath.Rel(s.chart.path, name)
+	if err != nil {
+		// note: this panic is ok because templates are always located below the chart directory
+		panic("this cannot happen")
+	}
+
+	data := make(map[string]any, len(s.data)+1)
+	for k, v := range s.data {
+		data[k] = v
+	}
+	data["Template"] = &Template{
+		Name:     s.path + "/" + filepath.ToSlash(relativeName),
+		BasePath: s.path + "/templates",
+	}
+
+	return t0.ExecuteTemplate(w, name, data)
+}
@@ -10,9 +10,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"testing/fstest"
 
 	"github.com/sap/go-generics/slices"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/apimachinery/pkg/version"
@@ -82,6 +84,356 @@ var _ = Describe("testing: chart.go", func() {
 		}()...,
 		)
 	})
+
+	Context("using: testdata/conformance", func() {
+		DescribeTable("testing: Render()", func() []any {
+			f := func(chartPath string, valuesPath string, manifestsPath string) {
+				chart, err := helm.ParseChart(os.DirFS("testdata"), chartPath, nil)
+				Expect(err).NotTo(HaveOccurred())
+				values, err := loadValues(valuesPath)
+				Expect(err).NotTo(HaveOccurred())
+				expectedObjects, err := loadManifests(manifestsPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				objects, err := chart.Render(helm.RenderContext{
+					DiscoveryClient: clientset.Discovery(),
+					Release: &helm.Release{
+						Namespace: "my-namespace",
+						Name:      "my-name",
+						Service:   "Helm",
+						IsInstall: true,
+						Revision:  1,
+					},
+					Values: values,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(objects).To(ConsistOf(expectedObjects))
+			}
+			paths, err := filepath.Glob("testdata/conformance/*/values/*.yaml")
+			Expect(err).NotTo(HaveOccurred())
+			entries := slices.Collect(paths, func(path string) any {
+				name := filepath.Base(path)
+				dir := filepath.Dir(filepath.Dir(path))
+				return Entry(nil, fmt.Sprintf("conformance/%s/chart", filepath.Base(dir)), path, fmt.Sprintf("%s/manifests/%s", dir, name))
+			})
+			return append([]any{f}, entries...)
+		}()...,
+		)
+
+		It("should refuse to render library charts", func() {
+			chart, err := helm.ParseChart(os.DirFS("testdata"), "conformance/library/chart/charts/common", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = chart.Render(helm.RenderContext{
+				DiscoveryClient: clientset.Discovery(),
+				Release:         &helm.Release{Namespace: "my-namespace", Name: "my-name"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("not installable")))
+		})
+	})
+
+	Context("using: testdata/schema", func() {
+		var chart *helm.Chart
+
+		BeforeEach(func() {
+			var err error
+			chart, err = helm.ParseChart(os.DirFS("testdata"), "schema", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		render := func(values map[string]any) ([]client.Object, error) {
+			return chart.Render(helm.RenderContext{
+				DiscoveryClient: clientset.Discovery(),
+				Release: &helm.Release{
+					Namespace: "my-namespace",
+					Name:      "my-name",
+					Service:   "Helm",
+					IsInstall: true,
+					Revision:  1,
+				},
+				Values: values,
+			})
+		}
+
+		It("should render values matching values.schema.json", func() {
+			objects, err := render(map[string]any{"replicaCount": int64(3)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(2))
+		})
+
+		It("should reject values not matching values.schema.json", func() {
+			_, err := render(map[string]any{"replicaCount": int64(0)})
+			Expect(err).To(MatchError(And(ContainSubstring("chart schema"), ContainSubstring("replicaCount: "))))
+		})
+
+		It("should reject values not matching values.schema.json of subcharts", func() {
+			_, err := render(map[string]any{"sub": map[string]any{"enabled": "yes"}})
+			Expect(err).To(MatchError(And(ContainSubstring("chart sub"), ContainSubstring("enabled: "))))
+		})
+	})
+
+	Context("using: testdata/archive", func() {
+		render := func(chart *helm.Chart) ([]client.Object, error) {
+			return chart.Render(helm.RenderContext{
+				DiscoveryClient: clientset.Discovery(),
+				Release: &helm.Release{
+					Namespace: "my-namespace",
+					Name:      "my-name",
+					Service:   "Helm",
+					IsInstall: true,
+					Revision:  1,
+				},
+			})
+		}
+
+		data := func(objects []client.Object) map[string]map[string]any {
+			result := make(map[string]map[string]any)
+			for _, object := range objects {
+				result[object.GetName()] = object.(*unstructured.Unstructured).Object["data"].(map[string]any)
+			}
+			return result
+		}
+
+		It("should render packaged charts, including packaged subcharts", func() {
+			chart, err := helm.ParseChart(os.DirFS("testdata"), "archive/mychart-0.1.0.tgz", nil)
+			Expect(err).NotTo(HaveOccurred())
+			objects, err := render(chart)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data(objects)).To(Equal(map[string]map[string]any{
+				"my-name-mychart": {"template": "mychart/templates/configmap.yaml"},
+				"my-name-sub":     {"template": "mychart/charts/sub/templates/configmap.yaml", "message": "hello from mychart"},
+			}))
+		})
+
+		It("should render charts with packaged subcharts", func() {
+			chart, err := helm.ParseChart(os.DirFS("testdata"), "archive/parent", nil)
+			Expect(err).NotTo(HaveOccurred())
+			objects, err := render(chart)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data(objects)).To(Equal(map[string]map[string]any{
+				"my-name-parent": {"template": "parent/templates/configmap.yaml"},
+				"my-name-sub":    {"template": "parent/charts/sub/templates/configmap.yaml", "message": "hello from mychart"},
+			}))
+		})
+
+		It("should reject invalid chart archives", func() {
+			_, err := helm.ParseChart(os.DirFS("testdata"), "archive/parent/Chart.yaml", nil)
+			Expect(err).To(MatchError(ContainSubstring("error reading chart archive")))
+		})
+	})
+
+	Context("using: testdata/ignore", func() {
+		render := func(chart *helm.Chart) ([]client.Object, error) {
+			return chart.Render(helm.RenderContext{
+				DiscoveryClient: clientset.Discovery(),
+				Release: &helm.Release{
+					Namespace: "my-namespace",
+					Name:      "my-name",
+					Service:   "Helm",
+					IsInstall: true,
+					Revision:  1,
+				},
+			})
+		}
+
+		It("should honour .helmignore for templates, files and subcharts", func() {
+			chart, err := helm.ParseChart(os.DirFS("testdata"), "ignore", nil)
+			Expect(err).NotTo(HaveOccurred())
+			helmIgnore, err := os.ReadFile("testdata/ignore/.helmignore")
+			Expect(err).NotTo(HaveOccurred())
+			objects, err := render(chart)
+			Expect(err).NotTo(HaveOccurred())
+			data := make(map[string]any)
+			for _, object := range objects {
+				data[object.GetName()] = object.(*unstructured.Unstructured).Object["data"]
+			}
+			Expect(data).To(Equal(map[string]any{
+				// note: the .helmignore of the top-level chart applies to subcharts, whereas the .helmignore of the subchart is not evaluated;
+				// as with helm, .helmignore files themselves are accessible through the Files builtin
+				"my-name-sub":    map[string]any{"keep.txt": "keep\n", ".helmignore": "keep.txt\n"},
+				"my-name-ignore": map[string]any{"files/a.txt": "a\n", "files/sub/top.txt": "top\n", ".helmignore": string(helmIgnore)},
+			}))
+		})
+
+		It("should ignore all paths not matching a negated rule", func() {
+			chart, err := helm.ParseChart(fstest.MapFS{
+				"negate/Chart.yaml":               {Data: []byte("apiVersion: v2\nname: negate\nversion: 0.1.0\n")},
+				"negate/.helmignore":              {Data: []byte("!*.yaml\n")},
+				"negate/values.yaml":              {Data: []byte("name: test\n")},
+				"negate/templates/configmap.yaml": {Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Values.name }}\n")},
+			}, "negate", nil)
+			Expect(err).NotTo(HaveOccurred())
+			objects, err := render(chart)
+			Expect(err).NotTo(HaveOccurred())
+			// note: as with helm, the directory 'templates' does not match the negated rule, and is therefore ignored
+			Expect(objects).To(BeEmpty())
+		})
+
+		It("should reject invalid .helmignore files", func() {
+			_, err := helm.ParseChart(fstest.MapFS{
+				"invalid/Chart.yaml":  {Data: []byte("apiVersion: v2\nname: invalid\nversion: 0.1.0\n")},
+				"invalid/.helmignore": {Data: []byte("templates/**/*.yaml\n")},
+			}, "invalid", nil)
+			Expect(err).To(MatchError(ContainSubstring("double-star")))
+		})
+	})
+
+	Context("using: builtin objects", func() {
+		var chart *helm.Chart
+
+		BeforeEach(func() {
+			fakeDiscovery := clientset.Discovery().(*discoveryfake.FakeDiscovery)
+			fakeDiscovery.FakedServerVersion = &version.Info{
+				Major:      "1",
+				Minor:      "30",
+				GitVersion: "v1.30.2",
+			}
+			fakeDiscovery.Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: "policy/v1",
+					APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget"}},
+				},
+			}
+
+			var err error
+			chart, err = helm.ParseChart(fstest.MapFS{
+				"builtins/Chart.yaml": {Data: []byte("apiVersion: v2\nname: builtins\nversion: 0.1.0\ndescription: test chart\nmaintainers:\n- name: someone\ndependencies:\n- name: sub\n  alias: mysub\n")},
+				"builtins/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-{{ .Chart.Name }}
+data:
+  hasKind: {{ .Capabilities.APIVersions.Has "policy/v1/PodDisruptionBudget" | quote }}
+  hasGroupVersion: {{ .Capabilities.APIVersions.Has "policy/v1" | quote }}
+  hasMissingKind: {{ .Capabilities.APIVersions.Has "policy/v1beta1/PodDisruptionBudget" | quote }}
+  kubeVersion: {{ .Capabilities.KubeVersion | quote }}
+  kubeGitVersion: {{ .Capabilities.KubeVersion.GitVersion | quote }}
+  kubeMinor: {{ .Capabilities.KubeVersion.Minor | quote }}
+  helm3: {{ semverCompare "^3.0.0" .Capabilities.HelmVersion.Version | quote }}
+  templateName: {{ .Template.Name | quote }}
+  templateBasePath: {{ .Template.BasePath | quote }}
+  chartDescription: {{ .Chart.Description | quote }}
+  chartMaintainer: {{ (first .Chart.Maintainers).Name | quote }}
+  subchartName: {{ .Subcharts.mysub.Chart.Name | quote }}
+  subchartMessage: {{ .Subcharts.mysub.Values.message | quote }}
+  isInstall: {{ .Release.IsInstall | quote }}
+  isUpgrade: {{ .Release.IsUpgrade | quote }}
+`)},
+				"builtins/charts/sub/Chart.yaml":  {Data: []byte("apiVersion: v2\nname: sub\nversion: 0.2.0\n")},
+				"builtins/charts/sub/values.yaml": {Data: []byte("message: hello\n")},
+				"builtins/charts/sub/templates/nested/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-{{ .Chart.Name }}
+data:
+  templateName: {{ .Template.Name | quote }}
+  templateBasePath: {{ .Template.BasePath | quote }}
+`)},
+			}, "builtins", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		render := func(isInstall bool) map[string]any {
+			objects, err := chart.Render(helm.RenderContext{
+				DiscoveryClient: clientset.Discovery(),
+				Release: &helm.Release{
+					Namespace: "my-namespace",
+					Name:      "my-name",
+					Service:   "Helm",
+					IsInstall: isInstall,
+					IsUpgrade: !isInstall,
+					Revision:  1,
+				},
+				Values: map[string]any{"mysub": map[string]any{"message": "hello from parent"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			data := make(map[string]any)
+			for _, object := range objects {
+				data[object.GetName()] = object.(*unstructured.Unstructured).Object["data"]
+			}
+			return data
+		}
+
+		It("should populate the builtin objects the same way as helm does", func() {
+			Expect(render(true)).To(Equal(map[string]any{
+				"my-name-builtins": map[string]any{
+					"hasKind":          "true",
+					"hasGroupVersion":  "true",
+					"hasMissingKind":   "false",
+					"kubeVersion":      "v1.30.2",
+					"kubeGitVersion":   "v1.30.2",
+					"kubeMinor":        "30",
+					"helm3":            "true",
+					"templateName":     "builtins/templates/configmap.yaml",
+					"templateBasePath": "builtins/templates",
+					"chartDescription": "test chart",
+					"chartMaintainer":  "someone",
+					"subchartName":     "mysub",
+					"subchartMessage":  "hello from parent",
+					"isInstall":        "true",
+					"isUpgrade":        "false",
+				},
+				"my-name-mysub": map[string]any{
+					"templateName":     "builtins/charts/mysub/templates/nested/configmap.yaml",
+					"templateBasePath": "builtins/charts/mysub/templates",
+				},
+			}))
+		})
+
+		It("should set Release.IsUpgrade when upgrading", func() {
+			data := render(false)["my-name-builtins"].(map[string]any)
+			Expect(data["isInstall"]).To(Equal("false"))
+			Expect(data["isUpgrade"]).To(Equal("true"))
+		})
+	})
+
+	Context("using: NOTES.txt", func() {
+		renderContext := func() helm.RenderContext {
+			return helm.RenderContext{
+				DiscoveryClient: clientset.Discovery(),
+				Release: &helm.Release{
+					Namespace: "my-namespace",
+					Name:      "my-name",
+					Service:   "Helm",
+					IsInstall: true,
+					Revision:  1,
+				},
+				Values: map[string]any{"port": int64(8080)},
+			}
+		}
+
+		It("should render the notes of the top-level chart only", func() {
+			chart, err := helm.ParseChart(fstest.MapFS{
+				"notes/Chart.yaml":                     {Data: []byte("apiVersion: v2\nname: notes\nversion: 0.1.0\n")},
+				"notes/templates/_helpers.tpl":         {Data: []byte(`{{- define "notes.fullname" -}}{{ .Release.Name }}-{{ .Chart.Name }}{{- end -}}`)},
+				"notes/templates/configmap.yaml":       {Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ include \"notes.fullname\" . }}\n")},
+				"notes/templates/NOTES.txt":            {Data: []byte("Service {{ include \"notes.fullname\" . }} listens on port {{ .Values.port }} ({{ .Template.Name }}).\n")},
+				"notes/charts/sub/Chart.yaml":          {Data: []byte("apiVersion: v2\nname: sub\nversion: 0.1.0\n")},
+				"notes/charts/sub/templates/NOTES.txt": {Data: []byte("Notes of sub.\n")},
+			}, "notes", nil)
+			Expect(err).NotTo(HaveOccurred())
+			notes, err := chart.RenderNotes(renderContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(Equal("Service my-name-notes listens on port 8080 (notes/templates/NOTES.txt).\n"))
+			objects, err := chart.Render(renderContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(objects).To(HaveLen(1))
+			Expect(objects[0].GetName()).To(Equal("my-name-notes"))
+			objects, notes, err = chart.RenderWithNotes(renderContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(Equal("Service my-name-notes listens on port 8080 (notes/templates/NOTES.txt).\n"))
+			Expect(objects).To(HaveLen(1))
+			Expect(objects[0].GetName()).To(Equal("my-name-notes"))
+		})
+
+		It("should return empty notes if the chart has no NOTES.txt", func() {
+			chart, err := helm.ParseChart(os.DirFS("testdata"), "main", nil)
+			Expect(err).NotTo(HaveOccurred())
+			notes, err := chart.RenderNotes(renderContext())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notes).To(BeEmpty())
+		})
+	})
 })
 
 func loadValues(path string) (map[string]any, error) {
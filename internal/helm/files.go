@@ -39,7 +39,7 @@ func (f Files) GetBytes(name string) []byte {
 
 func (f Files) Lines(name string) []string {
 	data, ok := f[name]
-	if !ok {
+	if !ok || len(data) == 0 {
 		return []string{}
 	}
 	s := string(data)
@@ -88,15 +88,15 @@ func (f Files) AsSecrets() string {
 	return string(util.Must(kyaml.Marshal(secretData)))
 }
 
+// Check whether the given file is reserved by helm (and therefore not returned by the Files builtin); note that, as with helm,
+// files such as LICENSE, README.md or .helmignore, as well as CRDs, are accessible through the Files builtin.
 func isIgnored(name string) bool {
 	return name == "Chart.yaml" ||
 		name == "Chart.lock" ||
-		name == "LICENSE" ||
-		name == "README.md" ||
+		name == "requirements.yaml" ||
+		name == "requirements.lock" ||
 		name == "values.yaml" ||
 		name == "values.schema.json" ||
-		name == ".helmignore" ||
 		strings.HasPrefix(name, "charts/") ||
-		strings.HasPrefix(name, "crds/") ||
 		strings.HasPrefix(name, "templates/")
 }
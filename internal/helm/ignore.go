@@ -0,0 +1,139 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const helmIgnoreFile = ".helmignore"
+
+// ignoreRules implements the semantics of .helmignore files, as implemented by helm (see helm.sh/helm/v3/pkg/ignore);
+// paths passed to ignored() are interpreted relative to root (which is the directory of the chart containing the .helmignore file);
+// as with helm, the rules of the top-level chart apply to the whole directory tree, including subcharts.
+type ignoreRules struct {
+	root     string
+	patterns []*ignorePattern
+}
+
+type ignorePattern struct {
+	rule    string
+	negate  bool
+	mustDir bool
+	// whether only the base name is matched (this is the case if the rule contains no slash)
+	matchBase bool
+}
+
+// Parse .helmignore rules; the default rules added by helm (ignoring hidden files in the templates directory) are always added.
+func parseIgnoreRules(root string, raw []byte) (*ignoreRules, error) {
+	rules := &ignoreRules{root: root}
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		if err := rules.parseRule(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", helmIgnoreFile, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := rules.parseRule("templates/.?*"); err != nil {
+		// note: this panic is ok because the default rule is valid
+		panic("this cannot happen")
+	}
+	return rules, nil
+}
+
+func (r *ignoreRules) parseRule(rule string) error {
+	rule = strings.TrimSpace(rule)
+	if rule == "" || strings.HasPrefix(rule, "#") {
+		return nil
+	}
+	if strings.Contains(rule, "**") {
+		return fmt.Errorf("double-star (**) syntax is not supported")
+	}
+	if _, err := filepath.Match(rule, "abc"); err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", rule, err)
+	}
+
+	p := &ignorePattern{}
+	if strings.HasPrefix(rule, "!") {
+		p.negate = true
+		rule = rule[1:]
+	}
+	if strings.HasSuffix(rule, "/") {
+		p.mustDir = true
+		rule = strings.TrimSuffix(rule, "/")
+	}
+	if strings.HasPrefix(rule, "/") {
+		rule = strings.TrimPrefix(rule, "/")
+	} else if !strings.Contains(rule, "/") {
+		p.matchBase = true
+	}
+	p.rule = rule
+
+	r.patterns = append(r.patterns, p)
+	return nil
+}
+
+// Check whether the given path (relative to the filesystem containing the chart) is ignored; this is the case if the path itself,
+// or one of its parent directories (up to the root) is ignored; note that (as with helm) ignored directories cannot be re-included
+// by later rules, because helm does not descend into ignored directories.
+func (r *ignoreRules) ignored(name string, isDir bool) bool {
+	if r == nil {
+		return false
+	}
+	rel, err := filepath.Rel(r.root, name)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if r.ignore(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return r.ignore(rel, isDir)
+}
+
+// Evaluate the rules against a single path, as helm does it; in particular, note that a negated pattern causes all paths
+// not matching the pattern to be ignored (without evaluating further rules).
+func (r *ignoreRules) ignore(name string, isDir bool) bool {
+	for _, p := range r.patterns {
+		if p.negate {
+			if p.mustDir && !isDir {
+				return true
+			}
+			if !p.match(name) {
+				return true
+			}
+			continue
+		}
+		if p.mustDir && !isDir {
+			continue
+		}
+		if p.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ignorePattern) match(name string) bool {
+	if p.matchBase {
+		name = path.Base(name)
+	}
+	ok, err := filepath.Match(p.rule, name)
+	return err == nil && ok
+}
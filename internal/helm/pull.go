@@ -0,0 +1,522 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	kyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	mediaTypeOciManifest   = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeChartContent  = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	maxChartArchiveSize    = 64 * 1024 * 1024
+	maxRepositoryIndexSize = 64 * 1024 * 1024
+	maxTagListPages        = 100
+)
+
+var wwwAuthenticateParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+var linkNextPattern = regexp.MustCompile(`<([^>]*)>\s*;\s*rel="?next"?`)
+
+// ChartReference identifies a chart in an OCI registry or in a (classic) chart repository.
+type ChartReference struct {
+	// URL of the chart repository (http or https), or of the chart itself if it resides in an OCI registry (oci://<host>/<repository>).
+	URL string
+	// Name of the chart (only relevant for chart repositories).
+	Name string
+	// Exact version or version constraint; if empty, the latest stable version is used.
+	Version string
+	// Digest (sha256:<hex>) pinning the chart; for OCI registries, this is the digest of the manifest,
+	// for chart repositories, this is the digest of the chart archive.
+	Digest string
+}
+
+// PullOptions configure how charts are retrieved.
+type PullOptions struct {
+	// Directory where chart archives and extracted charts are cached.
+	CacheDir string
+	// Returns the credentials to be used for the given host (including port, if any).
+	Credentials func(host string) (username string, password string, ok bool)
+	// HTTP client to be used.
+	HTTPClient *http.Client
+	// Whether OCI registries are accessed through plain HTTP.
+	PlainHTTP bool
+}
+
+// Pull a chart from an OCI registry or chart repository into the cache directory (unless it is already there).
+// Returns the path of the cached chart archive, which can be passed to ParseChart().
+func PullChart(ctx context.Context, ref ChartReference, options PullOptions) (string, error) {
+	if options.HTTPClient == nil {
+		options.HTTPClient = http.DefaultClient
+	}
+	if options.Credentials == nil {
+		options.Credentials = func(host string) (string, string, bool) { return "", "", false }
+	}
+	if ref.Digest != "" {
+		if _, err := parseDigest(ref.Digest); err != nil {
+			return "", err
+		}
+	}
+
+	u, err := url.Parse(ref.URL)
+	if err != nil {
+		return "", err
+	}
+	cache := &chartCache{dir: options.CacheDir}
+
+	var archiveDigest string
+	switch u.Scheme {
+	case "oci":
+		archiveDigest, err = pullOciChart(ctx, u, ref, options, cache)
+	case "http", "https":
+		archiveDigest, err = pullRepositoryChart(ctx, u, ref, options, cache)
+	default:
+		return "", fmt.Errorf("unsupported chart url scheme: %s", u.Scheme)
+	}
+	if err != nil {
+		return "", err
+	}
+	return cache.path(archiveDigest), nil
+}
+
+func pullOciChart(ctx context.Context, u *url.URL, ref ChartReference, options PullOptions, cache *chartCache) (string, error) {
+	scheme := "https"
+	if options.PlainHTTP {
+		scheme = "http"
+	}
+	clnt := &registryClient{
+		httpClient: options.HTTPClient,
+		baseUrl:    fmt.Sprintf("%s://%s/v2/%s", scheme, u.Host, strings.Trim(u.Path, "/")),
+		repository: strings.Trim(u.Path, "/"),
+	}
+	clnt.username, clnt.password, _ = options.Credentials(u.Host)
+
+	var manifestRaw []byte
+	if ref.Digest != "" {
+		if raw, ok := cache.get(ref.Digest); ok {
+			manifestRaw = raw
+		}
+	}
+	if manifestRaw == nil {
+		reference := ref.Digest
+		if reference == "" {
+			tag, err := clnt.resolveTag(ctx, ref.Version)
+			if err != nil {
+				return "", err
+			}
+			reference = tag
+		}
+		raw, err := clnt.get(ctx, "/manifests/"+reference, mediaTypeOciManifest)
+		if err != nil {
+			return "", fmt.Errorf("error retrieving manifest %s of %s: %w", reference, ref.URL, err)
+		}
+		if ref.Digest != "" {
+			if err := cache.put(ref.Digest, raw); err != nil {
+				return "", err
+			}
+		}
+		manifestRaw = raw
+	}
+
+	manifest := struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}{}
+	if err := json.Unmarshal(manifestRaw, &manifest); err != nil {
+		return "", fmt.Errorf("error parsing manifest of %s: %w", ref.URL, err)
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaTypeChartContent {
+			continue
+		}
+		if _, err := parseDigest(layer.Digest); err != nil {
+			return "", err
+		}
+		if _, ok := cache.get(layer.Digest); ok {
+			return layer.Digest, nil
+		}
+		raw, err := clnt.get(ctx, "/blobs/"+layer.Digest, "")
+		if err != nil {
+			return "", fmt.Errorf("error retrieving chart content of %s: %w", ref.URL, err)
+		}
+		if err := cache.put(layer.Digest, raw); err != nil {
+			return "", err
+		}
+		return layer.Digest, nil
+	}
+	return "", fmt.Errorf("manifest of %s does not contain chart content (media type %s)", ref.URL, mediaTypeChartContent)
+}
+
+func pullRepositoryChart(ctx context.Context, u *url.URL, ref ChartReference, options PullOptions, cache *chartCache) (string, error) {
+	if ref.Name == "" {
+		return "", fmt.Errorf("chart name must be specified for chart repository %s", ref.URL)
+	}
+	// note: if the chart is pinned by digest, and already cached, the repository is not contacted at all
+	if ref.Digest != "" {
+		if _, ok := cache.get(ref.Digest); ok {
+			return ref.Digest, nil
+		}
+	}
+	username, password, haveCredentials := options.Credentials(u.Host)
+
+	get := func(rawUrl string, maxSize int64) ([]byte, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawUrl, nil)
+		if err != nil {
+			return nil, err
+		}
+		// note: as helm does it, credentials are only passed to the host of the repository
+		if haveCredentials && request.URL.Host == u.Host {
+			request.SetBasicAuth(username, password)
+		}
+		response, err := options.HTTPClient.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected http status retrieving %s: %s", rawUrl, response.Status)
+		}
+		return readLimited(response.Body, maxSize)
+	}
+
+	indexUrl := u.JoinPath("index.yaml")
+	indexRaw, err := get(indexUrl.String(), maxRepositoryIndexSize)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving index of chart repository %s: %w", ref.URL, err)
+	}
+	index := struct {
+		Entries map[string][]struct {
+			Version string   `json:"version"`
+			Digest  string   `json:"digest"`
+			URLs    []string `json:"urls"`
+		} `json:"entries"`
+	}{}
+	if err := kyaml.Unmarshal(indexRaw, &index); err != nil {
+		return "", fmt.Errorf("error parsing index of chart repository %s: %w", ref.URL, err)
+	}
+	entries, ok := index.Entries[ref.Name]
+	if !ok {
+		return "", fmt.Errorf("chart %s not found in chart repository %s", ref.Name, ref.URL)
+	}
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, entry.Version)
+	}
+	version, err := selectVersion(versions, ref.Version)
+	if err != nil {
+		return "", fmt.Errorf("error selecting version of chart %s in chart repository %s: %w", ref.Name, ref.URL, err)
+	}
+	for _, entry := range entries {
+		if entry.Version != version {
+			continue
+		}
+		if len(entry.URLs) == 0 {
+			return "", fmt.Errorf("chart %s (version %s) in chart repository %s has no download url", ref.Name, version, ref.URL)
+		}
+		archiveUrl, err := indexUrl.Parse(entry.URLs[0])
+		if err != nil {
+			return "", err
+		}
+		raw, err := get(archiveUrl.String(), maxChartArchiveSize)
+		if err != nil {
+			return "", fmt.Errorf("error retrieving chart %s (version %s) from chart repository %s: %w", ref.Name, version, ref.URL, err)
+		}
+		digest := calculateDigest(raw)
+		if entry.Digest != "" && "sha256:"+entry.Digest != digest {
+			return "", fmt.Errorf("digest of chart %s (version %s) does not match the digest in the index of chart repository %s", ref.Name, version, ref.URL)
+		}
+		if ref.Digest != "" && ref.Digest != digest {
+			return "", fmt.Errorf("digest of chart %s (version %s) does not match the requested digest %s (actual digest: %s)", ref.Name, version, ref.Digest, digest)
+		}
+		if err := cache.put(digest, raw); err != nil {
+			return "", err
+		}
+		return digest, nil
+	}
+	panic("this cannot happen")
+}
+
+// registryClient implements the subset of the OCI distribution api needed to pull charts; it supports anonymous access,
+// basic authentication, and token authentication (as used by most registries).
+type registryClient struct {
+	httpClient *http.Client
+	baseUrl    string
+	repository string
+	username   string
+	password   string
+	token      string
+}
+
+func (c *registryClient) get(ctx context.Context, path string, accept string) ([]byte, error) {
+	raw, _, err := c.getUrl(ctx, c.baseUrl+path, accept)
+	if err != nil {
+		return nil, err
+	}
+	// note: content retrieved by digest is always verified
+	if reference := strings.TrimPrefix(strings.TrimPrefix(path, "/manifests/"), "/blobs/"); strings.HasPrefix(reference, "sha256:") {
+		if digest := calculateDigest(raw); digest != reference {
+			return nil, fmt.Errorf("digest mismatch (expected: %s, actual: %s)", reference, digest)
+		}
+	}
+	return raw, nil
+}
+
+// Retrieve the given url (which must point to the registry), and return the response body and the url of the next page (as announced
+// by the Link header of the response, see https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-tags), if any.
+func (c *registryClient) getUrl(ctx context.Context, rawUrl string, accept string) ([]byte, string, error) {
+	response, err := c.do(ctx, rawUrl, accept)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusUnauthorized {
+		if err := c.authenticate(ctx, response.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, "", err
+		}
+		response.Body.Close()
+		response, err = c.do(ctx, rawUrl, accept)
+		if err != nil {
+			return nil, "", err
+		}
+		defer response.Body.Close()
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected http status: %s", response.Status)
+	}
+	raw, err := readLimited(response.Body, maxChartArchiveSize)
+	if err != nil {
+		return nil, "", err
+	}
+	nextUrl := ""
+	if m := linkNextPattern.FindStringSubmatch(response.Header.Get("Link")); m != nil {
+		// note: the link is usually relative to the registry (such as /v2/<repository>/tags/list?n=<n>&last=<tag>); credentials
+		// must not be sent to other hosts, so links pointing elsewhere are rejected
+		u, err := response.Request.URL.Parse(m[1])
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid link to next page: %w", err)
+		}
+		if u.Scheme != response.Request.URL.Scheme || u.Host != response.Request.URL.Host {
+			return nil, "", fmt.Errorf("invalid link to next page: %s (pointing to another host)", m[1])
+		}
+		nextUrl = u.String()
+	}
+	return raw, nextUrl, nil
+}
+
+func (c *registryClient) do(ctx context.Context, rawUrl string, accept string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.username != "" || c.password != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+	return c.httpClient.Do(request)
+}
+
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("registry requires unsupported authentication scheme: %s", scheme)
+	}
+	values := url.Values{}
+	realm := ""
+	for _, m := range wwwAuthenticateParamPattern.FindAllStringSubmatch(params, -1) {
+		switch m[1] {
+		case "realm":
+			realm = m[2]
+		case "service", "scope":
+			values.Set(m[1], m[2])
+		}
+	}
+	if realm == "" {
+		return fmt.Errorf("registry authentication challenge has no realm")
+	}
+	if values.Get("scope") == "" {
+		values.Set("scope", fmt.Sprintf("repository:%s:pull", c.repository))
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" || c.password != "" {
+		request.SetBasicAuth(c.username, c.password)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("error retrieving registry token: unexpected http status: %s", response.Status)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return fmt.Errorf("error parsing registry token: %w", err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("registry returned an empty token")
+	}
+	return nil
+}
+
+// Resolve the tag to be pulled; exact versions are used as they are, otherwise the tags of the repository are listed
+// (following the pagination links returned by the registry), and the latest version matching the given constraint is selected.
+func (c *registryClient) resolveTag(ctx context.Context, version string) (string, error) {
+	// note: '+' is not allowed in tags, so helm replaces it by '_'
+	if _, err := semver.StrictNewVersion(version); err == nil {
+		return strings.ReplaceAll(version, "+", "_"), nil
+	}
+	var versions []string
+	for page, rawUrl := 0, c.baseUrl+"/tags/list"; rawUrl != ""; page++ {
+		if page == maxTagListPages {
+			return "", fmt.Errorf("error listing tags: too many pages (maximum: %d)", maxTagListPages)
+		}
+		raw, nextUrl, err := c.getUrl(ctx, rawUrl, "")
+		if err != nil {
+			return "", fmt.Errorf("error listing tags: %w", err)
+		}
+		tags := struct {
+			Tags []string `json:"tags"`
+		}{}
+		if err := json.Unmarshal(raw, &tags); err != nil {
+			return "", fmt.Errorf("error parsing tags: %w", err)
+		}
+		for _, tag := range tags.Tags {
+			versions = append(versions, strings.ReplaceAll(tag, "_", "+"))
+		}
+		rawUrl = nextUrl
+	}
+	version, err := selectVersion(versions, version)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(version, "+", "_"), nil
+}
+
+// Select the latest version matching the given constraint; if the constraint is empty, the latest stable version is selected;
+// versions which are not valid semantic versions are ignored.
+func selectVersion(versions []string, constraint string) (string, error) {
+	if constraint == "" {
+		constraint = "*"
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint %s: %w", constraint, err)
+	}
+	var candidates []*semver.Version
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil {
+			continue
+		}
+		if c.Check(v) {
+			candidates = append(candidates, v)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no version matching %s found", constraint)
+	}
+	sort.Sort(semver.Collection(candidates))
+	return candidates[len(candidates)-1].Original(), nil
+}
+
+// chartCache stores content by digest (in <dir>/sha256/<hex>).
+type chartCache struct {
+	dir string
+}
+
+func (c *chartCache) path(digest string) string {
+	hex, err := parseDigest(digest)
+	if err != nil {
+		// note: this panic is ok because all digests are validated before
+		panic("this cannot happen")
+	}
+	return filepath.Join(c.dir, "sha256", hex)
+}
+
+func (c *chartCache) get(digest string) ([]byte, bool) {
+	raw, err := os.ReadFile(c.path(digest))
+	if err != nil || calculateDigest(raw) != digest {
+		return nil, false
+	}
+	return raw, true
+}
+
+func (c *chartCache) put(digest string, raw []byte) error {
+	if actualDigest := calculateDigest(raw); actualDigest != digest {
+		return fmt.Errorf("digest mismatch (expected: %s, actual: %s)", digest, actualDigest)
+	}
+	if err := os.MkdirAll(filepath.Join(c.dir, "sha256"), 0755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Join(c.dir, "sha256"), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(raw); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), c.path(digest))
+}
+
+func readLimited(r io.Reader, maxSize int64) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > maxSize {
+		return nil, fmt.Errorf("content exceeds maximum size of %d bytes", maxSize)
+	}
+	return raw, nil
+}
+
+func parseDigest(digest string) (string, error) {
+	hex, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok || len(hex) != 64 || strings.ToLower(hex) != hex || strings.Trim(hex, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid digest %s (must be sha256:<hex>)", digest)
+	}
+	return hex, nil
+}
+
+func calculateDigest(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
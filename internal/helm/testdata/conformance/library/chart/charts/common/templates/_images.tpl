@@ -0,0 +1,11 @@
+{{- define "common.images.image" -}}
+{{- $registryName := .imageRoot.registry -}}
+{{- if and .global .global.imageRegistry -}}
+{{- $registryName = .global.imageRegistry -}}
+{{- end -}}
+{{- if $registryName -}}
+{{- printf "%s/%s:%s" $registryName .imageRoot.repository (.imageRoot.tag | toString) -}}
+{{- else -}}
+{{- printf "%s:%s" .imageRoot.repository (.imageRoot.tag | toString) -}}
+{{- end -}}
+{{- end -}}
@@ -13,11 +13,32 @@ import (
 // +kubebuilder:object:generate=true
 
 type ChartMetadata struct {
+	APIVersion   string            `json:"apiVersion,omitempty"`
 	Name         string            `json:"name,omitempty"`
 	Version      string            `json:"version,omitempty"`
+	KubeVersion  string            `json:"kubeVersion,omitempty"`
+	Description  string            `json:"description,omitempty"`
 	Type         string            `json:"type,omitempty"`
+	Keywords     []string          `json:"keywords,omitempty"`
+	Home         string            `json:"home,omitempty"`
+	Sources      []string          `json:"sources,omitempty"`
+	Maintainers  []ChartMaintainer `json:"maintainers,omitempty"`
+	Icon         string            `json:"icon,omitempty"`
 	AppVersion   string            `json:"appVersion,omitempty"`
+	Deprecated   bool              `json:"deprecated,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
 	Dependencies []ChartDependency `json:"dependencies,omitempty"`
+	// Condition and Tags are unused (deprecated by helm), but still exposed through the Chart builtin
+	Condition string `json:"condition,omitempty"`
+	Tags      string `json:"tags,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+type ChartMaintainer struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	URL   string `json:"url,omitempty"`
 }
 
 const (
@@ -29,6 +50,8 @@ const (
 
 type ChartDependency struct {
 	Name         string             `json:"name,omitempty"`
+	Version      string             `json:"version,omitempty"`
+	Repository   string             `json:"repository,omitempty"`
 	Alias        string             `json:"alias,omitempty"`
 	Condition    string             `json:"condition,omitempty"`
 	Tags         []string           `json:"tags,omitempty"`
@@ -48,7 +71,9 @@ func (v *ChartImportValue) UnmarshalJSON(b []byte) error {
 		if err := json.Unmarshal(b, &s); err != nil {
 			return err
 		}
+		// note: as with helm, the short form imports the according key below the child's exports into the root of the parent's values
 		v.Child = fmt.Sprintf("exports.%s", s)
+		v.Parent = "."
 	} else {
 		type chartImportValue ChartImportValue
 		w := chartImportValue(*v)
@@ -83,6 +108,7 @@ type Release struct {
 type Capabilities struct {
 	KubeVersion KubeVersion `json:"kubeVersion,omitempty"`
 	APIVersions ApiVersions `json:"apiVersions,omitempty"`
+	HelmVersion HelmVersion `json:"helmVersion,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -95,12 +121,22 @@ type KubeVersion struct {
 	GitVersion string `json:"gitVersion,omitempty"`
 }
 
-func (kubeVersion *KubeVersion) String() string {
+func (kubeVersion KubeVersion) String() string {
 	return kubeVersion.Version
 }
 
 // +kubebuilder:object:generate=true
 
+// HelmVersion describes the helm version emulated by this package (exposed as .Capabilities.HelmVersion).
+type HelmVersion struct {
+	Version      string `json:"version,omitempty"`
+	GitCommit    string `json:"gitCommit,omitempty"`
+	GitTreeState string `json:"gitTreeState,omitempty"`
+	GoVersion    string `json:"goVersion,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
 type ApiVersions []string
 
 func (apiVersions ApiVersions) Has(version string) bool {
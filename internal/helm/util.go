@@ -6,7 +6,6 @@ SPDX-License-Identifier: Apache-2.0
 package helm
 
 import (
-	"fmt"
 	"strings"
 )
 
@@ -172,28 +171,6 @@ func digMap(data map[string]any, paths ...string) (map[string]any, bool, bool) {
 	return nil, false, false
 }
 
-func undig(data map[string]any, value any, paths ...string) error {
-	var keys []string
-	for _, path := range paths {
-		keys = append(keys, splitPath(path)...)
-	}
-	if len(keys) == 0 {
-		panic("cannot undig into an empty path")
-	}
-	for _, key := range keys[0 : len(keys)-1] {
-		if value, ok := data[key]; ok {
-			if _, ok := value.(map[string]any); !ok {
-				return fmt.Errorf("cannot undig into fields which are not a string-keyed map")
-			}
-		} else {
-			data[key] = make(map[string]any)
-		}
-		data = data[key].(map[string]any)
-	}
-	data[keys[len(keys)-1]] = value
-	return nil
-}
-
 func splitPath(s string) []string {
 	// TODO: allow dots in s to be escaped by backslash
 	if s == "" {
@@ -0,0 +1,164 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sap/go-generics/slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// The functions in this file implement the semantics of helm when combining values (see helm.sh/helm/v3/pkg/chartutil/coalesce.go).
+// Note that, where helm just logs a warning (for example, if a table would be overwritten by a non-table value), the according values
+// are skipped silently.
+
+const globalKey = "global"
+
+// A node in the tree of charts, used to coalesce values.
+type valuesNode struct {
+	// name of the chart (respectively the alias, as specified in the parent's dependencies)
+	name string
+	// default values of the chart
+	defaults map[string]any
+	// subcharts considered when coalescing values
+	children []*valuesNode
+}
+
+// Coalesce the given values with the default values of the chart represented by node (and recursively its subcharts),
+// as helm does it (see CoalesceValues() and MergeValues() in helm.sh/helm/v3/pkg/chartutil). The given values take precedence
+// over the defaults; globals are propagated from parent charts to subcharts (again, parent values take precedence); if merge is false,
+// keys set to null are removed. The result is a deep copy; in particular, the passed maps are not modified.
+// Subchart sections of the returned values are identical (that is, the same map) as the values returned for the according subchart.
+func coalesceValues(values map[string]any, node *valuesNode, merge bool) (map[string]any, error) {
+	var result map[string]any
+	if values == nil {
+		result = make(map[string]any)
+	} else {
+		result = runtime.DeepCopyJSON(values)
+	}
+
+	childNames := slices.Collect(node.children, func(child *valuesNode) string { return child.name })
+	for key, value := range node.defaults {
+		if v, ok := result[key]; ok {
+			if v == nil && !merge {
+				delete(result, key)
+			} else if dst, ok := v.(map[string]any); ok {
+				if src, ok := value.(map[string]any); ok {
+					// note: as helm does it, null values in subchart sections are retained, such that they can be evaluated when coalescing the subchart's values
+					coalesceTables(dst, src, merge || slices.Contains(childNames, key))
+				}
+			}
+		} else {
+			result[key] = runtime.DeepCopyJSONValue(value)
+		}
+	}
+
+	for _, child := range node.children {
+		v, ok := result[child.name]
+		if !ok {
+			v = make(map[string]any)
+			result[child.name] = v
+		}
+		childValues, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("type mismatch on %s: %T", child.name, v)
+		}
+		coalesceGlobals(childValues, result)
+		childValues, err := coalesceValues(childValues, child, merge)
+		if err != nil {
+			return nil, err
+		}
+		result[child.name] = childValues
+	}
+
+	return result, nil
+}
+
+// Coalesce src into dst (such that dst takes precedence); dst will be modified, src will not be modified;
+// if merge is false, keys set to null in dst are removed, if they exist in src.
+func coalesceTables(dst map[string]any, src map[string]any, merge bool) map[string]any {
+	for key, value := range src {
+		if v, ok := dst[key]; ok && v == nil && !merge {
+			delete(dst, key)
+		} else if !ok {
+			dst[key] = runtime.DeepCopyJSONValue(value)
+		} else if s, ok := value.(map[string]any); ok {
+			if d, ok := v.(map[string]any); ok {
+				coalesceTables(d, s, merge)
+			}
+		}
+	}
+	return dst
+}
+
+// Same as coalesceTables() (with merge set to true), but tables of src are not copied; instead, they are linked into dst. This reproduces
+// what helm does when importing values from subcharts (see processImportValues() in helm.sh/helm/v3/pkg/chartutil): if multiple imports
+// go to the same parent path, then the later imports are merged into the table of the first import, which therefore also modifies the
+// values of the subchart that table was imported from.
+func importTables(dst map[string]any, src map[string]any) map[string]any {
+	for key, value := range src {
+		if v, ok := dst[key]; !ok {
+			dst[key] = value
+		} else if s, ok := value.(map[string]any); ok {
+			if d, ok := v.(map[string]any); ok {
+				importTables(d, s)
+			}
+		}
+	}
+	return dst
+}
+
+// Merge the globals of src into the globals of dst, such that the globals of src take precedence; dst will be modified, src will not be modified.
+func coalesceGlobals(dst map[string]any, src map[string]any) {
+	var dstGlobals, srcGlobals map[string]any
+	if v, ok := dst[globalKey]; !ok {
+		dstGlobals = make(map[string]any)
+	} else if dstGlobals, ok = v.(map[string]any); !ok {
+		return
+	}
+	if v, ok := src[globalKey]; !ok {
+		srcGlobals = make(map[string]any)
+	} else if srcGlobals, ok = v.(map[string]any); !ok {
+		return
+	}
+
+	for key, value := range srcGlobals {
+		if s, ok := value.(map[string]any); ok {
+			s = runtime.DeepCopyJSON(s)
+			if v, ok := dstGlobals[key]; !ok {
+				dstGlobals[key] = s
+			} else if d, ok := v.(map[string]any); ok {
+				dstGlobals[key] = coalesceTables(s, d, true)
+			}
+		} else if v, ok := dstGlobals[key]; ok && isTable(v) {
+			continue
+		} else {
+			dstGlobals[key] = runtime.DeepCopyJSONValue(value)
+		}
+	}
+	dst[globalKey] = dstGlobals
+}
+
+// Convert a (dot-separated) path and a table into a nested table, as helm does it when importing values from subcharts;
+// the special path "." denotes the root.
+func pathToMap(path string, data map[string]any) map[string]any {
+	if path == "." {
+		return data
+	}
+	keys := strings.Split(path, ".")
+	for i := len(keys) - 1; i >= 0; i-- {
+		data = map[string]any{keys[i]: data}
+	}
+	return data
+}
+
+func isTable(value any) bool {
+	_, ok := value.(map[string]any)
+	return ok
+}
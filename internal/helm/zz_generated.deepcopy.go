@@ -39,6 +39,7 @@ func (in *Capabilities) DeepCopyInto(out *Capabilities) {
 		*out = make(ApiVersions, len(*in))
 		copy(*out, *in)
 	}
+	out.HelmVersion = in.HelmVersion
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Capabilities.
@@ -91,9 +92,46 @@ func (in *ChartImportValue) DeepCopy() *ChartImportValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartMaintainer) DeepCopyInto(out *ChartMaintainer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartMaintainer.
+func (in *ChartMaintainer) DeepCopy() *ChartMaintainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartMaintainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChartMetadata) DeepCopyInto(out *ChartMetadata) {
 	*out = *in
+	if in.Keywords != nil {
+		in, out := &in.Keywords, &out.Keywords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Maintainers != nil {
+		in, out := &in.Maintainers, &out.Maintainers
+		*out = make([]ChartMaintainer, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Dependencies != nil {
 		in, out := &in.Dependencies, &out.Dependencies
 		*out = make([]ChartDependency, len(*in))
@@ -113,6 +151,21 @@ func (in *ChartMetadata) DeepCopy() *ChartMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmVersion) DeepCopyInto(out *HelmVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmVersion.
+func (in *HelmVersion) DeepCopy() *HelmVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVersion) DeepCopyInto(out *KubeVersion) {
 	*out = *in
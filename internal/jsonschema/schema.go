@@ -0,0 +1,126 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	jsonschemav6 "github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	kyaml "sigs.k8s.io/yaml"
+)
+
+const schemaLocation = "schema.json"
+
+var printer = message.NewPrinter(language.English)
+
+// Schema is a compiled JSON schema; schemas not declaring a dialect (through $schema) are considered to be draft 2020-12.
+type Schema struct {
+	schema *jsonschemav6.Schema
+}
+
+// Compile a JSON schema; the passed document may be JSON or YAML.
+// References ($ref) to external documents are not supported.
+func Compile(raw []byte) (*Schema, error) {
+	rawJson, err := kyaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := jsonschemav6.UnmarshalJSON(bytes.NewReader(rawJson))
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschemav6.NewCompiler()
+	compiler.DefaultDraft(jsonschemav6.Draft2020)
+	compiler.UseLoader(noLoader{})
+	if err := compiler.AddResource(schemaLocation, doc); err != nil {
+		return nil, err
+	}
+	schema, err := compiler.Compile(schemaLocation)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// Validate the given value against the schema; the value must be deeply JSON (that is, consist of maps, slices and scalar values only).
+// The returned error (if any) lists all violations, each prefixed with the path of the offending field (such as 'image.tag' or 'ports[1]').
+func (s *Schema) Validate(value any) error {
+	// note: roundtrip through JSON to normalize numbers and types
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	doc, err := jsonschemav6.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	if err := s.schema.Validate(doc); err != nil {
+		validationError := &jsonschemav6.ValidationError{}
+		if !errors.As(err, &validationError) {
+			return err
+		}
+		var messages []string
+		collectMessages(validationError, doc, &messages)
+		sort.Strings(messages)
+		return errors.New(strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+func collectMessages(validationError *jsonschemav6.ValidationError, doc any, messages *[]string) {
+	if len(validationError.Causes) == 0 {
+		path := formatPath(validationError.InstanceLocation, doc)
+		if path == "" {
+			path = "(root)"
+		}
+		*messages = append(*messages, fmt.Sprintf("%s: %s", path, validationError.ErrorKind.LocalizedString(printer)))
+		return
+	}
+	for _, cause := range validationError.Causes {
+		collectMessages(cause, doc, messages)
+	}
+}
+
+func formatPath(location []string, doc any) string {
+	var b strings.Builder
+	for _, token := range location {
+		switch v := doc.(type) {
+		case []any:
+			b.WriteString("[" + token + "]")
+			if i, err := strconv.Atoi(token); err == nil && i >= 0 && i < len(v) {
+				doc = v[i]
+			} else {
+				doc = nil
+			}
+		default:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(token)
+			if m, ok := v.(map[string]any); ok {
+				doc = m[token]
+			} else {
+				doc = nil
+			}
+		}
+	}
+	return b.String()
+}
+
+type noLoader struct{}
+
+func (noLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("loading external schema %s is not supported", url)
+}
@@ -0,0 +1,73 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jsonschema_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/internal/jsonschema"
+)
+
+const testSchema = `{
+  "type": "object",
+  "required": ["image"],
+  "properties": {
+    "replicaCount": {"type": "integer", "minimum": 1},
+    "image": {
+      "type": "object",
+      "properties": {
+        "tag": {"type": "string"}
+      }
+    },
+    "ports": {
+      "type": "array",
+      "items": {"type": "integer"}
+    }
+  }
+}`
+
+var _ = Describe("testing: schema.go", func() {
+	var schema *jsonschema.Schema
+
+	BeforeEach(func() {
+		var err error
+		schema, err = jsonschema.Compile([]byte(testSchema))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should accept valid values", func() {
+		Expect(schema.Validate(map[string]any{
+			"replicaCount": int64(2),
+			"image":        map[string]any{"tag": "latest"},
+			"ports":        []any{int64(80), int64(443)},
+		})).To(Succeed())
+	})
+
+	It("should report violations with field paths", func() {
+		err := schema.Validate(map[string]any{
+			"replicaCount": int64(0),
+			"image":        map[string]any{"tag": int64(1)},
+			"ports":        []any{int64(80), "https"},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("replicaCount: "))
+		Expect(err.Error()).To(ContainSubstring("image.tag: "))
+		Expect(err.Error()).To(ContainSubstring("ports[1]: "))
+		Expect(schema.Validate(map[string]any{})).To(MatchError(ContainSubstring("(root): ")))
+	})
+
+	It("should accept YAML and honor the declared dialect", func() {
+		schema, err := jsonschema.Compile([]byte("$schema: http://json-schema.org/draft-07/schema#\ntype: object\nadditionalProperties: false\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(schema.Validate(map[string]any{"foo": "bar"})).To(MatchError(ContainSubstring("foo")))
+	})
+
+	It("should reject invalid schemas", func() {
+		_, err := jsonschema.Compile([]byte(`{"type": 1}`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -54,6 +54,9 @@ type KustomizationOptions struct {
 	RightTemplateDelimiter *string
 	// If defined, used to decrypt files
 	Decryptor manifests.Decryptor
+	// If defined, custom template functions, which are merged into the functions provided by templatex.FuncMap() (see templatex.MergeFuncMap());
+	// in contrast to the other options, custom functions are passed to referenced kustomizations as well
+	FuncMap template.FuncMap
 }
 
 type KustomizationConfiguration struct {
@@ -110,12 +113,15 @@ type Kustomization struct {
 // kustomizationPath are passed to the sub-kustomization. Of course the sub-kustomization can contain its own .component-config.yaml.
 // Alternative template delimiters (other than {{ and }}) can be specified in the effective options. If no alternative delimiters are specified,
 // then the default delimiters are used. All files in kustomizationPath (plus those in includedFiles) are subject to the given decryptor,
-// if prsesent.
-
-// TODO: add a way to pass custom template functions
-
+// if prsesent. Custom template functions can be supplied through the options; they must not collide with any existing function
+// (that is, the standard functions, or the functions bound at render time, such as include, lookup, or readFile).
 func ParseKustomization(fsys fs.FS, kustomizationPath string, options KustomizationOptions) (*Kustomization, error) {
-	kustomization, err := parseKustomization(fsys, kustomizationPath, options, nil)
+	funcs, err := templatex.MergeFuncMap(options.FuncMap, maps.Keys(funcMapForContext(nil, nil, nil, nil, "", 0, "", ""))...)
+	if err != nil {
+		return nil, err
+	}
+
+	kustomization, err := parseKustomization(fsys, kustomizationPath, options, funcs, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +129,7 @@ func ParseKustomization(fsys fs.FS, kustomizationPath string, options Kustomizat
 	return kustomization, nil
 }
 
-func parseKustomization(fsys fs.FS, kustomizationPath string, options KustomizationOptions, visitedKustomizationPaths []string) (*Kustomization, error) {
+func parseKustomization(fsys fs.FS, kustomizationPath string, options KustomizationOptions, funcs template.FuncMap, visitedKustomizationPaths []string) (*Kustomization, error) {
 	if fsys == nil {
 		fsys = os.DirFS("/")
 		absoluteKustomizationPath, err := filepath.Abs(kustomizationPath)
@@ -200,7 +206,7 @@ func parseKustomization(fsys fs.FS, kustomizationPath string, options Kustomizat
 				t.Delims(*config.LeftTemplateDelimiter, *config.RightTemplateDelimiter)
 				t.Option("missingkey=zero").
 					Funcs(sprig.TxtFuncMap()).
-					Funcs(templatex.FuncMap()).
+					Funcs(funcs).
 					Funcs(templatex.FuncMapForTemplate(nil)).
 					Funcs(templatex.FuncMapForLocalClient(nil)).
 					Funcs(templatex.FuncMapForClient(nil)).
@@ -273,7 +279,7 @@ func parseKustomization(fsys fs.FS, kustomizationPath string, options Kustomizat
 			// but we keep it to maintain symmetry with the IncludedFiles handling, and because of the better error message
 			return nil, fmt.Errorf("include path (%s) must not be in the kustomization path (%s)", path, kustomizationPath)
 		}
-		kustomization, err := parseKustomization(fsys, absolutePath, KustomizationOptions{}, visitedKustomizationPaths)
+		kustomization, err := parseKustomization(fsys, absolutePath, KustomizationOptions{}, funcs, visitedKustomizationPaths)
 		if err != nil {
 			return nil, err
 		}
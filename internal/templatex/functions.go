@@ -12,10 +12,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 
+	"github.com/Masterminds/sprig/v3"
 	"github.com/sap/go-generics/slices"
 	"github.com/spf13/cast"
 
@@ -89,6 +92,54 @@ func FuncMapForLocalClient(c client.Client) template.FuncMap {
 	}
 }
 
+// Merge the given custom functions into FuncMap(). Custom functions must not replace any existing function; that is, they must not collide
+// with the sprig functions, the functions returned by FuncMap(), the functions bound at render time (that is, the functions returned by
+// FuncMapForTemplate(), FuncMapForClient() and FuncMapForLocalClient()), or the additionally specified reserved names;
+// otherwise, or if a custom function is not a valid template function, an error is returned.
+func MergeFuncMap(funcs template.FuncMap, reserved ...string) (template.FuncMap, error) {
+	reservedFuncs := make(map[string]struct{})
+	for _, funcMap := range []template.FuncMap{sprig.TxtFuncMap(), FuncMap(), FuncMapForTemplate(nil), FuncMapForClient(nil), FuncMapForLocalClient(nil)} {
+		for name := range funcMap {
+			reservedFuncs[name] = struct{}{}
+		}
+	}
+	for _, name := range reserved {
+		reservedFuncs[name] = struct{}{}
+	}
+
+	result := FuncMap()
+	for name, f := range funcs {
+		if _, ok := reservedFuncs[name]; ok {
+			return nil, fmt.Errorf("custom template function %s collides with existing function", name)
+		}
+		if err := validateFunc(name, f); err != nil {
+			return nil, err
+		}
+		result[name] = f
+	}
+	return result, nil
+}
+
+// Check that the given function can be registered with text/template (which would panic otherwise).
+func validateFunc(name string, f any) error {
+	if name == "" {
+		return fmt.Errorf("invalid custom template function: empty name")
+	}
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return fmt.Errorf("invalid custom template function %s: name is not a valid identifier", name)
+		}
+	}
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func {
+		return fmt.Errorf("invalid custom template function %s: value is not a function", name)
+	}
+	if t := v.Type(); !(t.NumOut() == 1 || t.NumOut() == 2 && t.Out(1) == reflect.TypeFor[error]()) {
+		return fmt.Errorf("invalid custom template function %s: function must return one value, or one value and an error", name)
+	}
+	return nil
+}
+
 func toYaml(data any) (string, error) {
 	raw, err := kyaml.Marshal(data)
 	if err != nil {
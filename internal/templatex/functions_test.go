@@ -414,6 +414,42 @@ var _ = Describe("testing: functions.go", func() {
 
 	})
 
+	Describe("testing: MergeFuncMap", func() {
+
+		It("should merge custom functions into the standard functions", func() {
+			funcs, err := MergeFuncMap(template.FuncMap{
+				"custom": func() string { return "custom" },
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(funcs).To(HaveKey("custom"))
+			Expect(funcs).To(HaveKey("toJson"))
+		})
+
+		It("should fail if a custom function collides with a standard function", func() {
+			_, err := MergeFuncMap(template.FuncMap{"toYaml": func(data any) (string, error) { return "yaml", nil }})
+			Expect(err).To(HaveOccurred())
+			_, err = MergeFuncMap(template.FuncMap{"upper": func(s string) string { return s }})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if a custom function collides with a function bound at render time", func() {
+			_, err := MergeFuncMap(template.FuncMap{"lookup": func() string { return "" }})
+			Expect(err).To(HaveOccurred())
+			_, err = MergeFuncMap(template.FuncMap{"readFile": func() string { return "" }}, "readFile")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail with invalid custom functions", func() {
+			_, err := MergeFuncMap(template.FuncMap{"custom": 42})
+			Expect(err).To(HaveOccurred())
+			_, err = MergeFuncMap(template.FuncMap{"custom": func() {}})
+			Expect(err).To(HaveOccurred())
+			_, err = MergeFuncMap(template.FuncMap{"custom-func": func() string { return "" }})
+			Expect(err).To(HaveOccurred())
+		})
+
+	})
+
 	Describe("testing: include", func() {
 
 		var t *template.Template
@@ -0,0 +1,38 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+// End the given span (if not nil); if err is not nil, it will be recorded, and the span status will be set to error.
+func EndSpan(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Return span attributes identifying the given object.
+func ObjectSpanAttributes(key types.ObjectKey) []attribute.KeyValue {
+	gvk := key.GetObjectKind().GroupVersionKind()
+	return []attribute.KeyValue{
+		attribute.String("k8s.object.group", gvk.Group),
+		attribute.String("k8s.object.version", gvk.Version),
+		attribute.String("k8s.object.kind", gvk.Kind),
+		attribute.String("k8s.object.namespace", key.GetNamespace()),
+		attribute.String("k8s.object.name", key.GetName()),
+	}
+}
@@ -0,0 +1,64 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultCircuitBreakerThreshold = 5
+	defaultCircuitBreakerCoolDown  = 1 * time.Hour
+)
+
+// CircuitBreakerOptions configure the circuit breaker of the reconciler.
+// If a component fails the specified number of times in a row with the same error, it enters the Stalled state, and will not be
+// reconciled until the cool-down is over, or its spec changes, or a retry is requested by setting the annotation
+// <reconciler-name>/retry to a new value (such as the current timestamp).
+type CircuitBreakerOptions struct {
+	// Number of consecutive failures (with the same error) after which a component is stalled.
+	// If unspecified, 5 is assumed.
+	Threshold int
+	// Duration for which stalled components are not reconciled.
+	// If unspecified, 1 hour is assumed.
+	CoolDown time.Duration
+}
+
+// Record the outcome of a reconciliation; failed tells whether the reconciliation returned an error (before it was possibly turned into a
+// delayed retry). Only failures leaving the component in Error state are counted; components waiting for something (that is, returning a
+// RetriableError or a ReconcileError with ErrorStatePending, and therefore being in Pending state) are not considered as failing, and reset the count.
+// The failure is counted as consecutive failure if the previous reconciliation failed with the same message (according to the saved status);
+// note that status.ConsecutiveFailures (as opposed to the saved value) is used as base, since it might have been reset in the meantime.
+// Returns true if the failure threshold is reached (in which case the caller should set the component to Stalled state).
+func recordFailure(status *Status, savedStatus *Status, failed bool, threshold int) bool {
+	state, _, message := status.GetState()
+	if !failed || state != StateError {
+		status.ConsecutiveFailures = 0
+		return false
+	}
+	if _, _, savedMessage := savedStatus.GetState(); status.ConsecutiveFailures > 0 && savedMessage == message {
+		status.ConsecutiveFailures++
+	} else {
+		status.ConsecutiveFailures = 1
+	}
+	return status.ConsecutiveFailures >= threshold
+}
+
+// Check whether a stalled component shall remain stalled; returns the time when the cool-down ends, and true if the component
+// remains stalled; a stalled component is released if its generation changed, if a new retry annotation value was set,
+// or if the cool-down is over.
+func remainsStalled(status *Status, generation int64, retry string, now metav1.Time, coolDown time.Duration) (time.Time, bool) {
+	if status.State != StateStalled || status.StalledSince == nil {
+		return time.Time{}, false
+	}
+	if generation != status.ObservedGeneration || retry != status.LastHandledRetry {
+		return time.Time{}, false
+	}
+	until := status.StalledSince.Add(coolDown)
+	return until, now.Time.Before(until)
+}
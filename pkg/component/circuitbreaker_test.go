@@ -0,0 +1,106 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("testing: circuitbreaker.go", func() {
+	var status *Status
+
+	ginkgo.BeforeEach(func() {
+		status = &Status{ObservedGeneration: 1}
+	})
+
+	fail := func(message string) bool {
+		savedStatus := status.DeepCopy()
+		status.SetState(StateError, ReadyConditionReasonError, message)
+		return recordFailure(status, savedStatus, true, 3)
+	}
+
+	pend := func(message string) bool {
+		savedStatus := status.DeepCopy()
+		status.SetState(StatePending, ReadyConditionReasonRetrying, message)
+		return recordFailure(status, savedStatus, true, 3)
+	}
+
+	ginkgo.It("should count consecutive failures with the same error", func() {
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(2))
+		Expect(fail("Error B")).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(1))
+		Expect(fail("Error B")).To(BeFalse())
+		Expect(fail("Error B")).To(BeTrue())
+		Expect(status.ConsecutiveFailures).To(Equal(3))
+	})
+
+	ginkgo.It("should reset the failure count on success", func() {
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeFalse())
+		savedStatus := status.DeepCopy()
+		status.SetState(StateProcessing, ReadyConditionReasonProcessing, "Processing")
+		Expect(recordFailure(status, savedStatus, false, 3)).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(BeZero())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(1))
+	})
+
+	ginkgo.It("should not count retriable errors as failures", func() {
+		for range 10 {
+			Expect(pend("Waiting for dependency")).To(BeFalse())
+		}
+		Expect(status.ConsecutiveFailures).To(BeZero())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(pend("Error A")).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(BeZero())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(1))
+	})
+
+	ginkgo.It("should restart counting if the failure count was reset (e.g. by a retry request)", func() {
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeTrue())
+		status.SetState(StateStalled, ReadyConditionReasonStalled, "Error A")
+		status.ConsecutiveFailures = 0
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(status.ConsecutiveFailures).To(Equal(1))
+	})
+
+	ginkgo.It("should keep components stalled until cool-down, spec change or retry", func() {
+		now := metav1.Now()
+		status.SetState(StateStalled, ReadyConditionReasonStalled, "Error A")
+		status.StalledSince = &metav1.Time{Time: now.Add(-10 * time.Minute)}
+		status.LastHandledRetry = "1"
+
+		until, ok := remainsStalled(status, 1, "1", now, time.Hour)
+		Expect(ok).To(BeTrue())
+		Expect(until).To(Equal(now.Add(50 * time.Minute)))
+
+		_, ok = remainsStalled(status, 1, "1", now, 5*time.Minute)
+		Expect(ok).To(BeFalse())
+		_, ok = remainsStalled(status, 2, "1", now, time.Hour)
+		Expect(ok).To(BeFalse())
+		_, ok = remainsStalled(status, 1, "2", now, time.Hour)
+		Expect(ok).To(BeFalse())
+	})
+
+	ginkgo.It("should stall again if the next attempt after the cool-down fails with the same error", func() {
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeFalse())
+		Expect(fail("Error A")).To(BeTrue())
+		status.SetState(StateStalled, ReadyConditionReasonStalled, "Error A")
+		Expect(fail("Error A")).To(BeTrue())
+	})
+})
@@ -6,10 +6,14 @@ SPDX-License-Identifier: Apache-2.0
 package component
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"time"
 
+	legacyerrors "github.com/pkg/errors"
+	"github.com/sap/go-generics/slices"
+
 	"github.com/sap/component-operator-runtime/pkg/reconciler"
 )
 
@@ -110,6 +114,17 @@ func assertRetryConfiguration[T Component](component T) (RetryConfiguration, boo
 	return nil, false
 }
 
+// Check if given component or its spec implements BackoffConfiguration (and return it).
+func assertBackoffConfiguration[T Component](component T) (BackoffConfiguration, bool) {
+	if backoffConfiguration, ok := Component(component).(BackoffConfiguration); ok {
+		return backoffConfiguration, true
+	}
+	if backoffConfiguration, ok := getSpec(component).(BackoffConfiguration); ok {
+		return backoffConfiguration, true
+	}
+	return nil, false
+}
+
 // Check if given component or its spec implements TimeoutConfiguration (and return it).
 func assertTimeoutConfiguration[T Component](component T) (TimeoutConfiguration, bool) {
 	if timeoutConfiguration, ok := Component(component).(TimeoutConfiguration); ok {
@@ -154,6 +169,61 @@ func assertReapplyConfiguration[T Component](component T) (ReapplyConfiguration,
 	return nil, false
 }
 
+// Check if given component or its spec implements OutputConfiguration (and return it).
+func assertOutputConfiguration[T Component](component T) (OutputConfiguration, bool) {
+	if outputConfiguration, ok := Component(component).(OutputConfiguration); ok {
+		return outputConfiguration, true
+	}
+	if outputConfiguration, ok := getSpec(component).(OutputConfiguration); ok {
+		return outputConfiguration, true
+	}
+	return nil, false
+}
+
+// Check if given component or its spec implements MultiTargetConfiguration (and return it).
+func assertMultiTargetConfiguration[T Component](component T) (MultiTargetConfiguration, bool) {
+	if multiTargetConfiguration, ok := Component(component).(MultiTargetConfiguration); ok {
+		return multiTargetConfiguration, true
+	}
+	if multiTargetConfiguration, ok := getSpec(component).(MultiTargetConfiguration); ok {
+		return multiTargetConfiguration, true
+	}
+	return nil, false
+}
+
+// Check if given component or its spec implements ApprovalConfiguration (and return it).
+func assertApprovalConfiguration[T Component](component T) (ApprovalConfiguration, bool) {
+	if approvalConfiguration, ok := Component(component).(ApprovalConfiguration); ok {
+		return approvalConfiguration, true
+	}
+	if approvalConfiguration, ok := getSpec(component).(ApprovalConfiguration); ok {
+		return approvalConfiguration, true
+	}
+	return nil, false
+}
+
+// Check if given component or its spec implements TestConfiguration (and return it).
+func assertTestConfiguration[T Component](component T) (TestConfiguration, bool) {
+	if testConfiguration, ok := Component(component).(TestConfiguration); ok {
+		return testConfiguration, true
+	}
+	if testConfiguration, ok := getSpec(component).(TestConfiguration); ok {
+		return testConfiguration, true
+	}
+	return nil, false
+}
+
+// Check if given component or its spec implements MaintenanceWindowConfiguration (and return it).
+func assertMaintenanceWindowConfiguration[T Component](component T) (MaintenanceWindowConfiguration, bool) {
+	if maintenanceWindowConfiguration, ok := Component(component).(MaintenanceWindowConfiguration); ok {
+		return maintenanceWindowConfiguration, true
+	}
+	if maintenanceWindowConfiguration, ok := getSpec(component).(MaintenanceWindowConfiguration); ok {
+		return maintenanceWindowConfiguration, true
+	}
+	return nil, false
+}
+
 // Implement the PlacementConfiguration interface.
 func (s *PlacementSpec) GetDeploymentNamespace() string {
 	return s.Namespace
@@ -208,6 +278,14 @@ func (s *RetrySpec) GetRetryInterval() time.Duration {
 	return time.Duration(0)
 }
 
+// Implement the BackoffConfiguration interface.
+func (s *BackoffSpec) GetMaxBackoff() time.Duration {
+	if s.MaxBackoff != nil {
+		return s.MaxBackoff.Duration
+	}
+	return time.Duration(0)
+}
+
 // Implement the TimeoutConfiguration interface.
 func (s *TimeoutSpec) GetTimeout() time.Duration {
 	if s.Timeout != nil {
@@ -246,6 +324,66 @@ func (s *ReapplySpec) GetReapplyInterval() time.Duration {
 	return time.Duration(0)
 }
 
+// Implement the OutputConfiguration interface.
+func (s *OutputSpec) GetConnectionSecretName() string {
+	return s.ConnectionSecretName
+}
+
+// Implement the MultiTargetConfiguration interface.
+func (s *MultiTargetSpec) GetTargets() ([]Target, error) {
+	var targets []Target
+	for _, target := range s.Targets {
+		var parameterOverrides map[string]any
+		if target.ParameterOverrides != nil {
+			if err := json.Unmarshal(target.ParameterOverrides.Raw, &parameterOverrides); err != nil {
+				return nil, legacyerrors.Wrapf(err, "error parsing parameter overrides of target %s", target.Name)
+			}
+		}
+		targets = append(targets, Target{
+			Name:                target.Name,
+			KubeConfig:          target.KubeConfig.SecretRef.value,
+			KubeConfigSecretRef: &SecretKeyReference{Namespace: target.KubeConfig.SecretRef.Namespace, Name: target.KubeConfig.SecretRef.Name, Key: target.KubeConfig.SecretRef.Key},
+			ParameterOverrides:  parameterOverrides,
+		})
+	}
+	return targets, nil
+}
+
+// Implement the MultiTargetConfiguration interface.
+func (s *MultiTargetSpec) GetRolloutStrategy() RolloutStrategy {
+	return s.RolloutStrategy
+}
+
+// Implement the ApprovalConfiguration interface.
+func (s *ApprovalSpec) IsApprovalRequired() bool {
+	return s.RequireApproval
+}
+
+// Implement the TestConfiguration interface.
+func (s *TestSpec) IsTestEnabled() bool {
+	return s.RunTests
+}
+
+// Implement the MaintenanceWindowConfiguration interface.
+func (s *MaintenanceWindowSpec) GetMaintenanceWindows() []MaintenanceWindow {
+	return s.MaintenanceWindows
+}
+
+// Implement the MaintenanceWindowConfiguration interface.
+func (s *MaintenanceWindowSpec) GetMaintenanceWindowDriftCorrectionPolicy() MaintenanceWindowPolicy {
+	return s.MaintenanceWindowDriftCorrectionPolicy
+}
+
+// Implement the MaintenanceWindowConfiguration interface.
+func (s *MaintenanceWindowSpec) GetMaintenanceWindowDeletionPolicy() MaintenanceWindowPolicy {
+	return s.MaintenanceWindowDeletionPolicy
+}
+
+// Check if plan contains no changes.
+func (p *Plan) isEmpty() bool {
+	return len(p.Items) == 0 && slices.All(p.Targets, func(t TargetPlan) bool { return len(t.Items) == 0 })
+}
+
 // Check if state is Ready.
 func (s *Status) IsReady() bool {
 	// caveat: this operates only on the status, so it does not check that observedGeneration == generation
@@ -280,6 +418,32 @@ func (s *Status) getOrAddCondition(condType ConditionType) *Condition {
 	return cond
 }
 
+// Remove condition (if existing).
+func (s *Status) removeCondition(condType ConditionType) {
+	s.Conditions = slices.Select(s.Conditions, func(cond Condition) bool { return cond.Type != condType })
+}
+
+// Set tested condition in status.
+// Note: this method does not touch the condition's LastTransitionTime.
+func (s *Status) setTested(condStatus ConditionStatus, reason string, message string) {
+	cond := s.getOrAddCondition(ConditionTypeTested)
+	cond.Status = condStatus
+	cond.Reason = reason
+	cond.Message = message
+}
+
+// Get target status (adding it if not existing).
+// Caveat: the returned pointer might become invalid if further appends happen to the Targets slice in the status object.
+func (s *Status) getOrAddTarget(name string) *TargetStatus {
+	for i := 0; i < len(s.Targets); i++ {
+		if s.Targets[i].Name == name {
+			return &s.Targets[i]
+		}
+	}
+	s.Targets = append(s.Targets, TargetStatus{Name: name})
+	return &s.Targets[len(s.Targets)-1]
+}
+
 // Get state (and related details).
 func (s *Status) GetState() (State, string, string) {
 	cond := s.getCondition(ConditionTypeReady)
@@ -296,7 +460,7 @@ func (s *Status) SetState(state State, reason string, message string) {
 	switch state {
 	case StateReady:
 		cond.Status = ConditionTrue
-	case StateError:
+	case StateError, StateStalled:
 		cond.Status = ConditionFalse
 	default:
 		cond.Status = ConditionUnknown
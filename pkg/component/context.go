@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/go-logr/logr"
+
 	"github.com/sap/component-operator-runtime/pkg/cluster"
 )
 
@@ -21,6 +23,7 @@ type (
 	componentNamespaceContextKeyType struct{}
 	componentDigestContextKeyType    struct{}
 	componentRevisionContextKeyType  struct{}
+	eventRecorderContextKeyType      struct{}
 )
 
 var (
@@ -32,8 +35,15 @@ var (
 	componentNamespaceContextKey = componentNamespaceContextKeyType{}
 	componentDigestContextKey    = componentDigestContextKeyType{}
 	componentRevisionContextKey  = componentRevisionContextKeyType{}
+	eventRecorderContextKey      = eventRecorderContextKeyType{}
 )
 
+// EventRecorder allows to emit events for a specific object (usually the component being reconciled).
+type EventRecorder interface {
+	Event(eventType string, reason string, message string)
+	Eventf(eventType string, reason string, messageFmt string, args ...any)
+}
+
 type Context interface {
 	context.Context
 	WithReconcilerName(reconcilerName string) Context
@@ -44,6 +54,8 @@ type Context interface {
 	WithComponentNamespace(componentNamespace string) Context
 	WithComponentDigest(componentDigest string) Context
 	WithComponentRevision(componentRevision int64) Context
+	WithLogger(logger logr.Logger) Context
+	WithEventRecorder(recorder EventRecorder) Context
 }
 
 func NewContext(ctx context.Context) Context {
@@ -86,6 +98,14 @@ func (c *contextImpl) WithComponentRevision(componentRevision int64) Context {
 	return &contextImpl{Context: context.WithValue(c, componentRevisionContextKey, componentRevision)}
 }
 
+func (c *contextImpl) WithLogger(logger logr.Logger) Context {
+	return &contextImpl{Context: logr.NewContext(c, logger)}
+}
+
+func (c *contextImpl) WithEventRecorder(recorder EventRecorder) Context {
+	return &contextImpl{Context: context.WithValue(c, eventRecorderContextKey, recorder)}
+}
+
 func ReconcilerNameFromContext(ctx context.Context) (string, error) {
 	if reconcilerName, ok := ctx.Value(reconcilerNameContextKey).(string); ok {
 		return reconcilerName, nil
@@ -142,3 +162,20 @@ func ComponentRevisionFromContext(ctx context.Context) (int64, error) {
 	}
 	return 0, fmt.Errorf("component revision not found in context")
 }
+
+// Note: the logger is stored in the same way as controller-runtime does it; therefore, log.FromContext() from
+// sigs.k8s.io/controller-runtime/pkg/log returns the same logger.
+func LoggerFromContext(ctx context.Context) (logr.Logger, error) {
+	logger, err := logr.FromContext(ctx)
+	if err != nil {
+		return logr.Discard(), fmt.Errorf("logger not found in context")
+	}
+	return logger, nil
+}
+
+func EventRecorderFromContext(ctx context.Context) (EventRecorder, error) {
+	if recorder, ok := ctx.Value(eventRecorderContextKey).(EventRecorder); ok {
+		return recorder, nil
+	}
+	return nil, fmt.Errorf("event recorder not found in context")
+}
@@ -0,0 +1,78 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/internal/events"
+)
+
+var _ = ginkgo.Describe("testing: context.go", func() {
+	ginkgo.It("should fail to retrieve logger and event recorder from a plain context", func() {
+		_, err := LoggerFromContext(context.Background())
+		Expect(err).To(HaveOccurred())
+		_, err = EventRecorderFromContext(context.Background())
+		Expect(err).To(HaveOccurred())
+	})
+
+	ginkgo.It("should retrieve logger and event recorder from an enhanced context", func() {
+		var messages []string
+		logger := logr.New(&testLogSink{messages: &messages})
+		fakeRecorder := record.NewFakeRecorder(10)
+		component := newTestComponent("default", "test")
+		ctx := NewContext(context.Background()).
+			WithLogger(logger).
+			WithEventRecorder(newEventRecorder(events.NewDeduplicatingRecorder(fakeRecorder, time.Minute), component))
+
+		logger, err := LoggerFromContext(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		logger.Info("from component helper")
+		log.FromContext(ctx).Info("from controller-runtime helper")
+		Expect(messages).To(Equal([]string{"from component helper", "from controller-runtime helper"}))
+
+		recorder, err := EventRecorderFromContext(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		recorder.Eventf(corev1.EventTypeNormal, "Test", "message %d", 1)
+		Expect(fakeRecorder.Events).To(Receive(Equal("Normal Test message 1")))
+	})
+})
+
+type testLogSink struct {
+	messages *[]string
+}
+
+func (s *testLogSink) Init(info logr.RuntimeInfo) {}
+
+func (s *testLogSink) Enabled(level int) bool {
+	return true
+}
+
+func (s *testLogSink) Info(level int, msg string, keysAndValues ...any) {
+	*s.messages = append(*s.messages, msg)
+}
+
+func (s *testLogSink) Error(err error, msg string, keysAndValues ...any) {
+	*s.messages = append(*s.messages, msg)
+}
+
+func (s *testLogSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return s
+}
+
+func (s *testLogSink) WithName(name string) logr.LogSink {
+	return s
+}
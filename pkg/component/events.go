@@ -0,0 +1,35 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sap/component-operator-runtime/internal/events"
+)
+
+// eventRecorder emits events for a fixed object.
+type eventRecorder struct {
+	recorder *events.DeduplicatingRecorder
+	object   client.Object
+}
+
+var _ EventRecorder = &eventRecorder{}
+
+func newEventRecorder(recorder *events.DeduplicatingRecorder, object client.Object) *eventRecorder {
+	return &eventRecorder{
+		recorder: recorder,
+		object:   object,
+	}
+}
+
+func (r *eventRecorder) Event(eventType string, reason string, message string) {
+	r.recorder.Event(r.object, eventType, reason, message)
+}
+
+func (r *eventRecorder) Eventf(eventType string, reason string, messageFmt string, args ...any) {
+	r.recorder.Eventf(r.object, eventType, reason, messageFmt, args...)
+}
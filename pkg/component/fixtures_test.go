@@ -0,0 +1,159 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/sap/component-operator-runtime/internal/events"
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+// Fixtures shared by the tests of this package.
+
+func newTestClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	scheme.AddKnownTypes(testGroupVersion, &testComponent{})
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(scheme)).
+		WithObjects(objects...).
+		WithStatusSubresource(&testComponent{}).
+		Build()
+}
+
+func newTestSecret(namespace string, name string, annotations map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: annotations,
+		},
+		Data: map[string][]byte{
+			"key": []byte(name),
+		},
+	}
+}
+
+// Return a reconciler which is wired with the given client (instead of the clients which would be created by SetupWithManager()).
+// Note that setupComplete is not set, such that hooks can still be registered.
+func newTestReconciler(clnt client.Client, options ReconcilerOptions) *Reconciler[*testComponent] {
+	r := NewReconciler[*testComponent]("test", nil, options)
+	r.client = cluster.NewClient(clnt, nil, &record.FakeRecorder{}, nil, nil)
+	r.hookClient = r.client
+	r.eventRecorder = *events.NewDeduplicatingRecorder(r.client.EventRecorder(), 5*time.Minute)
+	r.groupVersionKind = testGroupVersion.WithKind("testComponent")
+	r.controllerName = "testcomponent"
+	return r
+}
+
+var testGroupVersion = schema.GroupVersion{Group: "test.cs.sap.com", Version: "v1alpha1"}
+
+type testComponentSpec struct {
+	Secret          *SecretReference `json:"secret,omitempty"`
+	TargetSecret    *SecretReference `json:"targetSecret,omitempty" cluster:"target"`
+	BackoffSpec     `json:",inline"`
+	OutputSpec      `json:",inline"`
+	MultiTargetSpec `json:",inline"`
+}
+
+func (s *testComponentSpec) ToUnstructured() map[string]any {
+	result, err := runtime.DefaultUnstructuredConverter.ToUnstructured(s)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (s *testComponentSpec) DeepCopyInto(out *testComponentSpec) {
+	out.Secret = s.Secret.DeepCopy()
+	out.TargetSecret = s.TargetSecret.DeepCopy()
+	s.BackoffSpec.DeepCopyInto(&out.BackoffSpec)
+	s.OutputSpec.DeepCopyInto(&out.OutputSpec)
+	s.MultiTargetSpec.DeepCopyInto(&out.MultiTargetSpec)
+}
+
+type testComponent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              testComponentSpec `json:"spec,omitempty"`
+	Status            Status            `json:"status,omitempty"`
+}
+
+var _ Component = &testComponent{}
+
+func newTestComponent(namespace string, name string) *testComponent {
+	return &testComponent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: testGroupVersion.String(),
+			Kind:       "testComponent",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  namespace,
+			Name:       name,
+			Generation: 1,
+		},
+	}
+}
+
+func (c *testComponent) GetSpec() types.Unstructurable {
+	return &c.Spec
+}
+
+func (c *testComponent) GetStatus() *Status {
+	return &c.Status
+}
+
+func (c *testComponent) DeepCopyObject() runtime.Object {
+	out := &testComponent{}
+	out.TypeMeta = c.TypeMeta
+	c.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	c.Spec.DeepCopyInto(&out.Spec)
+	c.Status.DeepCopyInto(&out.Status)
+	return out
+}
+
+// Generator returning a config map (named as the component), containing the string parameters as data.
+type testGenerator struct{}
+
+func (g *testGenerator) Generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, error) {
+	data := make(map[string]string)
+	for key, value := range parameters.ToUnstructured() {
+		if value, ok := value.(string); ok {
+			data[key] = value
+		}
+	}
+	return []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       data,
+		},
+	}, nil
+}
+
+// Generator returning the given objects (or error), independently of the component.
+type testStaticGenerator struct {
+	objects []client.Object
+	err     error
+}
+
+func (g *testStaticGenerator) Generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, error) {
+	return g.objects, g.err
+}
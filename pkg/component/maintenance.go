@@ -0,0 +1,77 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"fmt"
+	"time"
+
+	legacyerrors "github.com/pkg/errors"
+)
+
+const maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// Check whether now is within one of the given maintenance windows; in addition, return the start of the next
+// maintenance window (after now). If no windows are given, now is considered to be within a maintenance window,
+// and the returned time is zero.
+func checkMaintenanceWindows(windows []MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+	active := false
+	var next time.Time
+	for i, window := range windows {
+		location := time.UTC
+		if window.TimeZone != "" {
+			var err error
+			location, err = time.LoadLocation(window.TimeZone)
+			if err != nil {
+				return false, time.Time{}, legacyerrors.Wrapf(err, "invalid time zone in maintenance window (%d)", i)
+			}
+		}
+		var hour, minute int
+		if _, err := fmt.Sscanf(window.Start, "%d:%d", &hour, &minute); err != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+			return false, time.Time{}, fmt.Errorf("invalid start time in maintenance window (%d): %s", i, window.Start)
+		}
+		duration := window.Duration.Duration
+		if duration <= 0 || duration > maxMaintenanceWindowDuration {
+			return false, time.Time{}, fmt.Errorf("invalid duration in maintenance window (%d): %s", i, duration)
+		}
+		days := make(map[time.Weekday]bool)
+		for _, day := range window.Days {
+			weekday, ok := weekdays[day]
+			if !ok {
+				return false, time.Time{}, fmt.Errorf("invalid day in maintenance window (%d): %s", i, day)
+			}
+			days[weekday] = true
+		}
+		localNow := now.In(location)
+		// note: since the duration is capped at 7 days, it is sufficient to look at the windows starting within one week around now
+		for offset := -8; offset <= 8; offset++ {
+			start := time.Date(localNow.Year(), localNow.Month(), localNow.Day()+offset, hour, minute, 0, 0, location)
+			if len(days) > 0 && !days[start.Weekday()] {
+				continue
+			}
+			if !start.After(now) && now.Before(start.Add(duration)) {
+				active = true
+			}
+			if start.After(now) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+	}
+	return active, next, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
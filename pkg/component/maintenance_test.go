@@ -0,0 +1,74 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("testing: maintenance.go", func() {
+	// note: 2026-10-17 is a Saturday
+	saturdayNight := MaintenanceWindow{
+		Days:     []string{"Saturday"},
+		Start:    "22:00",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+
+	ginkgo.It("should consider no windows as always active", func() {
+		active, next, err := checkMaintenanceWindows(nil, time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeTrue())
+		Expect(next.IsZero()).To(BeTrue())
+	})
+
+	ginkgo.It("should detect times within a window", func() {
+		active, next, err := checkMaintenanceWindows([]MaintenanceWindow{saturdayNight}, time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeTrue())
+		Expect(next).To(Equal(time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC)))
+	})
+
+	ginkgo.It("should detect times outside of a window", func() {
+		active, next, err := checkMaintenanceWindows([]MaintenanceWindow{saturdayNight}, time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeFalse())
+		Expect(next).To(Equal(time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC)))
+	})
+
+	ginkgo.It("should respect time zones and return the earliest next window", func() {
+		daily := MaintenanceWindow{
+			Start:    "03:00",
+			Duration: metav1.Duration{Duration: time.Hour},
+			TimeZone: "Europe/Berlin",
+		}
+		monday := MaintenanceWindow{
+			Days:     []string{"Monday"},
+			Start:    "00:00",
+			Duration: metav1.Duration{Duration: time.Hour},
+		}
+		active, next, err := checkMaintenanceWindows([]MaintenanceWindow{monday, daily}, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeFalse())
+		Expect(next).To(BeTemporally("==", time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)))
+	})
+
+	ginkgo.It("should reject invalid windows", func() {
+		for _, window := range []MaintenanceWindow{
+			{Start: "25:00", Duration: metav1.Duration{Duration: time.Hour}},
+			{Start: "01:00", Duration: metav1.Duration{Duration: 8 * 24 * time.Hour}},
+			{Start: "01:00", Duration: metav1.Duration{Duration: time.Hour}, Days: []string{"Caturday"}},
+			{Start: "01:00", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Nowhere/Special"},
+		} {
+			_, _, err := checkMaintenanceWindows([]MaintenanceWindow{window}, time.Now())
+			Expect(err).To(HaveOccurred())
+		}
+	})
+})
@@ -0,0 +1,261 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	legacyerrors "github.com/pkg/errors"
+	"github.com/sap/go-generics/slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/sap/component-operator-runtime/internal/util"
+)
+
+// multiReconcileTarget deploys the dependent objects of a component to multiple targets;
+// the inventory and state of each target is maintained in status.targets of the component.
+type multiReconcileTarget[T Component] struct {
+	targets []*reconcileTarget[T]
+	// targets which are no longer declared, but still have dependent objects; a removed target with empty name
+	// refers to the inventory maintained in status.inventory (that is, the component was switched to multi-target mode)
+	removedTargets  []*reconcileTarget[T]
+	rolloutStrategy RolloutStrategy
+}
+
+var _ componentTarget[Component] = &multiReconcileTarget[Component]{}
+
+func newMultiReconcileTarget[T Component](targets []*reconcileTarget[T], removedTargets []*reconcileTarget[T], rolloutStrategy RolloutStrategy) *multiReconcileTarget[T] {
+	return &multiReconcileTarget[T]{
+		targets:         targets,
+		removedTargets:  removedTargets,
+		rolloutStrategy: rolloutStrategy,
+	}
+}
+
+// Apply the component to all targets, respecting the rollout strategy; returns true if the component is ready on all targets.
+// Targets which are already rolling out the given component digest are always processed (such that their rollout completes,
+// and drift gets corrected); rollout to further targets is started only as long as the number of not ready targets stays
+// below the limit defined by the rollout strategy. Before that, the dependent objects of removed targets are deleted.
+func (t *multiReconcileTarget[T]) Apply(ctx context.Context, component T, componentDigest string) (bool, error) {
+	log := log.FromContext(ctx)
+	status := component.GetStatus()
+
+	// delete dependent objects from removed targets; the rollout to the declared targets is held until this is complete
+	// (such that objects moved from a removed target to a declared target on the same cluster are not deleted after they were applied)
+	ok, err := t.purgeRemovedTargets(ctx, component)
+	if err != nil {
+		return false, err
+	}
+
+	// drop status of targets which are no longer declared, and which have no dependent objects left; removed targets whose kubeconfig
+	// is not available are retained (including their inventory), such that their dependent objects get deleted once the kubeconfig is available again
+	status.Targets = slices.Select(status.Targets, func(s TargetStatus) bool {
+		if slices.Any(t.targets, func(target *reconcileTarget[T]) bool { return target.targetName == s.Name }) {
+			return true
+		}
+		return len(s.Inventory) > 0
+	})
+	for i := range status.Targets {
+		targetStatus := &status.Targets[i]
+		if slices.Any(t.targets, func(target *reconcileTarget[T]) bool { return target.targetName == targetStatus.Name }) ||
+			slices.Any(t.removedTargets, func(target *reconcileTarget[T]) bool { return target.targetName == targetStatus.Name }) {
+			continue
+		}
+		log.Info("kubeconfig of removed target not available; dependent objects cannot be deleted", "target", targetStatus.Name)
+		targetStatus.State = StateError
+		targetStatus.Message = "Target was removed, but its kubeconfig is not available; waiting until the kubeconfig is available to delete the dependent resources"
+	}
+	if !ok {
+		return false, nil
+	}
+
+	maxUnavailable := len(t.targets)
+	switch t.rolloutStrategy.Type {
+	case RolloutStrategyTypeOneAtATime:
+		maxUnavailable = 1
+	case RolloutStrategyTypeMaxUnavailable:
+		maxUnavailable = max(t.rolloutStrategy.MaxUnavailable, 1)
+	}
+
+	now := metav1.Now()
+	numUnavailable := 0
+	var errs []error
+	apply := func(target *reconcileTarget[T]) {
+		targetStatus := status.getOrAddTarget(target.targetName)
+		targetStatus.ProcessingDigest = componentDigest
+		targetStatus.KubeConfigSecretRef = target.kubeConfigSecretRef
+		ok, err := target.Apply(ctx, component, componentDigest)
+		// note: the target status must be retrieved again, because Apply() may have reallocated the status of the targets
+		targetStatus = status.getOrAddTarget(target.targetName)
+		switch {
+		case err != nil:
+			targetStatus.State = StateError
+			targetStatus.Message = capitalize(err.Error())
+			errs = append(errs, legacyerrors.Wrapf(err, "error reconciling target %s", target.targetName))
+			numUnavailable++
+		case ok:
+			targetStatus.State = StateReady
+			targetStatus.Message = "Dependent resources successfully reconciled"
+			targetStatus.LastAppliedAt = &now
+		default:
+			targetStatus.State = StateProcessing
+			targetStatus.Message = "Reconcilation of dependent resources triggered; waiting until all dependent resources are ready"
+			numUnavailable++
+		}
+	}
+
+	for _, target := range t.targets {
+		if status.getOrAddTarget(target.targetName).ProcessingDigest == componentDigest {
+			apply(target)
+		}
+	}
+	for _, target := range t.targets {
+		targetStatus := status.getOrAddTarget(target.targetName)
+		if targetStatus.ProcessingDigest == componentDigest {
+			continue
+		}
+		if numUnavailable >= maxUnavailable {
+			targetStatus.State = StatePending
+			targetStatus.Message = "Waiting for rollout to other targets"
+			continue
+		}
+		apply(target)
+	}
+
+	if len(errs) > 0 {
+		return false, errors.Join(errs...)
+	}
+	return slices.All(status.Targets, func(s TargetStatus) bool { return s.State == StateReady }), nil
+}
+
+// Calculate the changes which would be performed on each target.
+func (t *multiReconcileTarget[T]) Plan(ctx context.Context, component T, componentDigest string, revision int64) (*Plan, error) {
+	var targetPlans []TargetPlan
+	for _, target := range t.targets {
+		plan, err := target.Plan(ctx, component, componentDigest, revision)
+		if err != nil {
+			return nil, legacyerrors.Wrapf(err, "error calculating plan for target %s", target.targetName)
+		}
+		targetPlans = append(targetPlans, TargetPlan{Name: target.targetName, Items: plan.Items})
+	}
+	return &Plan{
+		Digest:  util.CalculateDigest(componentDigest, targetPlans),
+		Targets: targetPlans,
+	}, nil
+}
+
+// Validate the objects rendered for each target.
+func (t *multiReconcileTarget[T]) Validate(ctx context.Context, component T, componentDigest string, revision int64, dryRun bool) error {
+	for _, target := range t.targets {
+		if err := target.Validate(ctx, component, componentDigest, revision, dryRun); err != nil {
+			return legacyerrors.Wrapf(err, "error validating target %s", target.targetName)
+		}
+	}
+	return nil
+}
+
+// Outputs are not supported for components deployed to multiple targets; an error is returned if outputs were declared
+// (by the generator, or by annotations of dependent objects) during the preceding Apply() call on any target.
+func (t *multiReconcileTarget[T]) CollectOutputs(ctx context.Context) (map[string]string, map[string][]byte, error) {
+	for _, target := range t.targets {
+		if len(target.outputs) > 0 {
+			return nil, nil, fmt.Errorf("outputs are not supported for components with multiple targets (declared on target %s)", target.targetName)
+		}
+	}
+	return nil, nil, nil
+}
+
+// Return the notes rendered during the preceding Apply() call on each target, prefixed with the target name.
+func (t *multiReconcileTarget[T]) GetNotes() string {
+	var notes []string
+	for _, target := range t.targets {
+		if target.notes != "" {
+			notes = append(notes, fmt.Sprintf("Target %s:\n%s", target.targetName, strings.TrimRight(target.notes, "\n")))
+		}
+	}
+	return strings.Join(notes, "\n\n")
+}
+
+// Run the tests on all targets; returns true if the tests are completed on all targets.
+func (t *multiReconcileTarget[T]) Test(ctx context.Context, component T, componentDigest string) (bool, string, error) {
+	allTested := true
+	var msgs []string
+	for _, target := range t.targets {
+		ok, msg, err := target.Test(ctx, component, componentDigest)
+		if err != nil {
+			return false, "", legacyerrors.Wrapf(err, "error running tests on target %s", target.targetName)
+		}
+		if msg != "" {
+			msgs = append(msgs, fmt.Sprintf("%s (target %s)", msg, target.targetName))
+		}
+		allTested = allTested && ok
+	}
+	return allTested, strings.Join(msgs, "; "), nil
+}
+
+// Delete the dependent objects from all targets (including removed ones); returns true if the dependent objects are gone on all targets.
+func (t *multiReconcileTarget[T]) Delete(ctx context.Context, component T, componentDigest string) (bool, error) {
+	allDeleted, err := t.purgeRemovedTargets(ctx, component)
+	if err != nil {
+		return false, err
+	}
+	for _, target := range t.targets {
+		ok, err := target.Delete(ctx, component, componentDigest)
+		if err != nil {
+			return false, legacyerrors.Wrapf(err, "error deleting dependent resources from target %s", target.targetName)
+		}
+		allDeleted = allDeleted && ok
+	}
+	return allDeleted, nil
+}
+
+// Check if deletion is allowed on all targets.
+func (t *multiReconcileTarget[T]) IsDeletionAllowed(ctx context.Context, component T) (bool, string, error) {
+	var msgs []string
+	for _, target := range t.targets {
+		allowed, msg, err := target.IsDeletionAllowed(ctx, component)
+		if err != nil {
+			return false, "", legacyerrors.Wrapf(err, "error checking whether deletion is possible on target %s", target.targetName)
+		}
+		if !allowed {
+			msgs = append(msgs, fmt.Sprintf("%s (target %s)", msg, target.targetName))
+		}
+	}
+	if len(msgs) > 0 {
+		return false, strings.Join(msgs, "; "), nil
+	}
+	return true, "", nil
+}
+
+// Delete the dependent objects from all removed targets (without running delete hooks); returns true if the dependent objects are gone on all removed targets.
+func (t *multiReconcileTarget[T]) purgeRemovedTargets(ctx context.Context, component T) (bool, error) {
+	status := component.GetStatus()
+	allDeleted := true
+	for _, target := range t.removedTargets {
+		ok, err := target.Purge(ctx, component)
+		if target.targetName == "" {
+			if err != nil {
+				return false, legacyerrors.Wrap(err, "error deleting dependent resources from previous (single) target")
+			}
+		} else {
+			targetStatus := status.getOrAddTarget(target.targetName)
+			if err != nil {
+				targetStatus.State = StateError
+				targetStatus.Message = capitalize(err.Error())
+				return false, legacyerrors.Wrapf(err, "error deleting dependent resources from removed target %s", target.targetName)
+			}
+			targetStatus.State = StateDeleting
+			targetStatus.Message = "Target was removed; waiting until all dependent resources are deleted"
+		}
+		allDeleted = allDeleted && ok
+	}
+	return allDeleted, nil
+}
@@ -0,0 +1,223 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/internal/clientfactory"
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/reconciler"
+)
+
+var _ = ginkgo.Describe("testing: multitarget.go", func() {
+	var ctx context.Context
+	var component *testComponent
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		component = newTestComponent("default", "test")
+		component.Status.ProcessingDigest = "digest"
+	})
+
+	newTargets := func(names ...string) []*reconcileTarget[*testComponent] {
+		var targets []*reconcileTarget[*testComponent]
+		for _, name := range names {
+			clnt := cluster.NewClient(newTestClient(), nil, record.NewFakeRecorder(100), nil, nil)
+			target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, &testGenerator{}, reconciler.ReconcilerOptions{})
+			target.targetName = name
+			target.parameterOverrides = map[string]any{"target": name}
+			targets = append(targets, target)
+		}
+		return targets
+	}
+
+	getTargetStates := func() map[string]State {
+		states := make(map[string]State)
+		for _, targetStatus := range component.Status.Targets {
+			states[targetStatus.Name] = targetStatus.State
+		}
+		return states
+	}
+
+	ginkgo.It("should roll out to all targets at once", func() {
+		target := newMultiReconcileTarget(newTargets("a", "b"), nil, RolloutStrategy{})
+		ok, err := target.Apply(ctx, component, "digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateProcessing, "b": StateProcessing}))
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateReady, "b": StateReady}))
+		Expect(component.Status.Targets[0].Inventory).To(HaveLen(1))
+		Expect(component.Status.Targets[1].Inventory).To(HaveLen(1))
+		Expect(component.Status.Inventory).To(BeEmpty())
+	})
+
+	ginkgo.It("should roll out to one target after another", func() {
+		target := newMultiReconcileTarget(newTargets("a", "b"), nil, RolloutStrategy{Type: RolloutStrategyTypeOneAtATime})
+		ok, err := target.Apply(ctx, component, "digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateProcessing, "b": StatePending}))
+		Eventually(func() map[string]State {
+			_, err := target.Apply(ctx, component, "digest")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getTargetStates()).NotTo(Equal(map[string]State{"a": StateProcessing, "b": StateProcessing}))
+			return getTargetStates()
+		}).Should(Equal(map[string]State{"a": StateReady, "b": StateReady}))
+	})
+
+	ginkgo.It("should apply parameter overrides per target", func() {
+		targets := newTargets("a")
+		target := newMultiReconcileTarget(targets, nil, RolloutStrategy{})
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		configMap := &corev1.ConfigMap{}
+		Expect(targets[0].client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKeyWithValue("target", "a"))
+	})
+
+	ginkgo.It("should delete dependent objects from removed targets before rolling out", func() {
+		targets := newTargets("a", "b")
+		Eventually(func() (bool, error) {
+			return newMultiReconcileTarget(targets, nil, RolloutStrategy{}).Apply(ctx, component, "digest")
+		}).Should(BeTrue())
+		Expect(targets[1].client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).To(Succeed())
+
+		target := newMultiReconcileTarget(targets[:1], targets[1:], RolloutStrategy{})
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateReady}))
+		Expect(apierrors.IsNotFound(targets[1].client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}))).To(BeTrue())
+		Expect(targets[0].client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).To(Succeed())
+	})
+
+	ginkgo.It("should record the kubeconfig secret in the status of the targets", func() {
+		targets := newTargets("a")
+		targets[0].kubeConfigSecretRef = &SecretKeyReference{Namespace: "default", Name: "kubeconfig-a"}
+		_, err := newMultiReconcileTarget(targets, nil, RolloutStrategy{}).Apply(ctx, component, "digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.Status.Targets[0].KubeConfigSecretRef).To(Equal(&SecretKeyReference{Namespace: "default", Name: "kubeconfig-a"}))
+	})
+
+	ginkgo.It("should retain removed targets whose kubeconfig is not available", func() {
+		targets := newTargets("a", "b")
+		Eventually(func() (bool, error) {
+			return newMultiReconcileTarget(targets, nil, RolloutStrategy{}).Apply(ctx, component, "digest")
+		}).Should(BeTrue())
+
+		target := newMultiReconcileTarget(targets[:1], nil, RolloutStrategy{})
+		ok, err := target.Apply(ctx, component, "digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateReady, "b": StateError}))
+		Expect(component.Status.Targets[1].Inventory).To(HaveLen(1))
+		Expect(targets[1].client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).To(Succeed())
+
+		target = newMultiReconcileTarget(targets[:1], targets[1:], RolloutStrategy{})
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateReady}))
+		Expect(apierrors.IsNotFound(targets[1].client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	ginkgo.It("should load the kubeconfig of removed targets from the recorded secret", func() {
+		secret := newTestSecret("default", "kubeconfig-b", nil)
+		secret.Data = map[string][]byte{"value": []byte("kubeconfig")}
+		r := newTestReconciler(newTestClient(secret), ReconcilerOptions{})
+		kubeConfig, err := r.loadKubeConfigOfRemovedTarget(ctx, component, &SecretKeyReference{Namespace: "default", Name: "kubeconfig-b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(kubeConfig)).To(Equal("kubeconfig"))
+		kubeConfig, err = r.loadKubeConfigOfRemovedTarget(ctx, component, &SecretKeyReference{Namespace: "default", Name: "kubeconfig-c"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kubeConfig).To(BeNil())
+		kubeConfig, err = r.loadKubeConfigOfRemovedTarget(ctx, component, &SecretKeyReference{Namespace: "other", Name: "kubeconfig-b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kubeConfig).To(BeNil())
+	})
+
+	ginkgo.It("should delete dependent objects of the single target when switching to multiple targets", func() {
+		clnt := cluster.NewClient(newTestClient(), nil, record.NewFakeRecorder(100), nil, nil)
+		singleTarget := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, &testGenerator{}, reconciler.ReconcilerOptions{})
+		Eventually(func() (bool, error) { return singleTarget.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.Inventory).To(HaveLen(1))
+
+		target := newMultiReconcileTarget(newTargets("a"), []*reconcileTarget[*testComponent]{singleTarget}, RolloutStrategy{})
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.Inventory).To(BeEmpty())
+		Expect(getTargetStates()).To(Equal(map[string]State{"a": StateReady}))
+		Expect(apierrors.IsNotFound(clnt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	ginkgo.It("should fail to collect outputs declared on any target", func() {
+		targets := newTargets("a", "b")
+		target := newMultiReconcileTarget(targets, nil, RolloutStrategy{})
+		_, _, err := target.CollectOutputs(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		targets[1].outputs = []manifests.Output{{Name: "url"}}
+		_, _, err = target.CollectOutputs(ctx)
+		Expect(err).To(MatchError(ContainSubstring("outputs are not supported for components with multiple targets (declared on target b)")))
+	})
+
+	ginkgo.It("should reject a connection secret for components with multiple targets", func() {
+		r := newTestReconciler(newTestClient(), ReconcilerOptions{})
+		component.Spec.Targets = []TargetSpec{{Name: "a"}}
+		component.Spec.ConnectionSecretName = "test-connection"
+		_, err := r.getTargetForComponent(ctx, component, nil, nil, false)
+		Expect(err).To(MatchError(ContainSubstring("connection secret is not supported for components with multiple targets")))
+	})
+
+	ginkgo.It("should not remember kubeconfigs of targets when called from the admission webhook", func() {
+		r := newTestReconciler(newTestClient(), ReconcilerOptions{})
+		clients, err := clientfactory.NewClientFactory("test", "test", &rest.Config{Host: "https://127.0.0.1:1"}, nil, clientfactory.ClientFactoryOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		r.clients = clients
+		component.Spec.Targets = []TargetSpec{{Name: "a"}}
+		component.Spec.Targets[0].KubeConfig.SecretRef.value = []byte(testKubeConfig)
+		_, err = r.getTargetForComponent(ctx, component, nil, nil, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.targetKubeConfigs).To(BeEmpty())
+		_, err = r.getTargetForComponent(ctx, component, nil, nil, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.targetKubeConfigs).To(HaveLen(1))
+	})
+
+	ginkgo.It("should return the notes of all targets", func() {
+		targets := newTargets("a", "b", "c")
+		targets[0].notes = "notes of a\n"
+		targets[2].notes = "notes of c"
+		Expect(newMultiReconcileTarget(targets, nil, RolloutStrategy{}).GetNotes()).To(Equal("Target a:\nnotes of a\n\nTarget c:\nnotes of c"))
+	})
+
+	ginkgo.It("should return an error for invalid parameter overrides", func() {
+		spec := &MultiTargetSpec{Targets: []TargetSpec{{Name: "a", ParameterOverrides: &apiextensionsv1.JSON{Raw: []byte("[]")}}}}
+		_, err := spec.GetTargets()
+		Expect(err).To(MatchError(ContainSubstring("error parsing parameter overrides of target a")))
+	})
+})
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: test
+  context:
+    cluster: test
+users:
+- name: test
+current-context: test
+`
@@ -0,0 +1,227 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+
+	legacyerrors "github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	kyaml "sigs.k8s.io/yaml"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+// Get outputs declared by annotations on the given objects. The annotations <reconciler-name>/outputs and
+// <reconciler-name>/sensitive-outputs are expected to contain a map (in JSON or YAML format) from output names to
+// JSONPath templates (in kubectl syntax, such as {.spec.clusterIP}) which are evaluated against the annotated object.
+func getOutputsFromAnnotations(reconcilerName string, objects []client.Object) ([]manifests.Output, error) {
+	var outputs []manifests.Output
+	for _, object := range objects {
+		for _, sensitive := range []bool{false, true} {
+			annotationKey := reconcilerName + "/" + types.AnnotationKeySuffixOutputs
+			if sensitive {
+				annotationKey = reconcilerName + "/" + types.AnnotationKeySuffixSensitiveOutputs
+			}
+			value, ok := object.GetAnnotations()[annotationKey]
+			if !ok {
+				continue
+			}
+			var paths map[string]string
+			if err := kyaml.Unmarshal([]byte(value), &paths); err != nil {
+				return nil, legacyerrors.Wrapf(err, "error parsing annotation %s of object %s", annotationKey, types.ObjectKeyToString(object))
+			}
+			for name, path := range paths {
+				outputs = append(outputs, manifests.Output{
+					Name:      name,
+					Object:    object,
+					Path:      path,
+					Sensitive: sensitive,
+				})
+			}
+		}
+	}
+	return outputs, nil
+}
+
+// Read the given outputs from the according dependent objects (using the given client). Non-sensitive outputs are returned
+// as first return value (these are supposed to be written to the component's status); all outputs are returned as second return value
+// (these are supposed to be written to the connection secret). The given namespace is used for namespaced objects which have no namespace set.
+func collectOutputs(ctx context.Context, clnt client.Client, namespace string, outputs []manifests.Output) (map[string]string, map[string][]byte, error) {
+	publicOutputs := make(map[string]string)
+	allOutputs := make(map[string][]byte)
+	objects := make(map[string]*unstructured.Unstructured)
+	for _, output := range outputs {
+		if output.Name == "" {
+			return nil, nil, fmt.Errorf("invalid output declaration for object %s: name must not be empty", types.ObjectKeyToString(output.Object))
+		}
+		if _, ok := allOutputs[output.Name]; ok {
+			return nil, nil, fmt.Errorf("duplicate output %s", output.Name)
+		}
+		gvk := output.Object.GetObjectKind().GroupVersionKind()
+		if gvk.Empty() {
+			// note: typed objects returned by generators do not necessarily have their type information set
+			if object, ok := output.Object.(runtime.Object); ok {
+				var err error
+				if gvk, err = apiutil.GVKForObject(object, clnt.Scheme()); err != nil {
+					return nil, nil, legacyerrors.Wrapf(err, "error reading output %s", output.Name)
+				}
+			}
+		}
+		objectNamespace := output.Object.GetNamespace()
+		if objectNamespace == "" {
+			object := &unstructured.Unstructured{}
+			object.SetGroupVersionKind(gvk)
+			namespaced, err := clnt.IsObjectNamespaced(object)
+			if err != nil {
+				return nil, nil, legacyerrors.Wrapf(err, "error reading output %s", output.Name)
+			}
+			if namespaced {
+				objectNamespace = namespace
+			}
+		}
+		objectKey := fmt.Sprintf("%s %s/%s", gvk.String(), objectNamespace, output.Object.GetName())
+		object, ok := objects[objectKey]
+		if !ok {
+			object = &unstructured.Unstructured{}
+			object.SetGroupVersionKind(gvk)
+			if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: objectNamespace, Name: output.Object.GetName()}, object); err != nil {
+				return nil, nil, legacyerrors.Wrapf(err, "error reading output %s", output.Name)
+			}
+			objects[objectKey] = object
+		}
+		content, err := decodeBinaryFields(object)
+		if err != nil {
+			return nil, nil, legacyerrors.Wrapf(err, "error reading output %s", output.Name)
+		}
+		value, err := evaluateJsonPath(content, output.Path)
+		if err != nil {
+			return nil, nil, legacyerrors.Wrapf(err, "error reading output %s", output.Name)
+		}
+		if !output.Sensitive {
+			publicOutputs[output.Name] = value
+		}
+		allOutputs[output.Name] = []byte(value)
+	}
+	return publicOutputs, allOutputs, nil
+}
+
+// Return the content of the given object, with base64-encoded fields (that is, the data of secrets, and the binary data of config maps)
+// replaced by their decoded values; such that outputs can be evaluated against the plain values, regardless of the used JSONPath template.
+func decodeBinaryFields(object *unstructured.Unstructured) (map[string]any, error) {
+	gvk := object.GroupVersionKind()
+	var field string
+	switch {
+	case gvk.Group == "" && gvk.Kind == "Secret":
+		field = "data"
+	case gvk.Group == "" && gvk.Kind == "ConfigMap":
+		field = "binaryData"
+	default:
+		return object.UnstructuredContent(), nil
+	}
+	data, ok := object.Object[field].(map[string]any)
+	if !ok {
+		return object.UnstructuredContent(), nil
+	}
+	decodedData := make(map[string]any, len(data))
+	for key, value := range data {
+		encodedValue, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value for key %s in field %s", key, field)
+		}
+		decodedValue, err := base64.StdEncoding.DecodeString(encodedValue)
+		if err != nil {
+			return nil, legacyerrors.Wrapf(err, "error decoding key %s in field %s", key, field)
+		}
+		decodedData[key] = string(decodedValue)
+	}
+	content := maps.Clone(object.Object)
+	content[field] = decodedData
+	return content, nil
+}
+
+func evaluateJsonPath(content map[string]any, path string) (string, error) {
+	parser := jsonpath.New("output")
+	if err := parser.Parse(path); err != nil {
+		return "", legacyerrors.Wrapf(err, "error parsing jsonpath %s", path)
+	}
+	var buf bytes.Buffer
+	if err := parser.Execute(&buf, content); err != nil {
+		return "", legacyerrors.Wrapf(err, "error evaluating jsonpath %s", path)
+	}
+	return buf.String(), nil
+}
+
+// Create or update the connection secret of a component; the secret is created in the component's namespace,
+// and is owned by the component (such that it will be garbage-collected when the component is deleted).
+func writeConnectionSecret(ctx context.Context, clnt client.Client, component Component, name string, data map[string][]byte, fieldOwner string) error {
+	secret := &corev1.Secret{}
+	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: component.GetNamespace(), Name: name}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return legacyerrors.Wrapf(err, "error reading connection secret %s/%s", component.GetNamespace(), name)
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: component.GetNamespace(),
+				Name:      name,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(component, secret, clnt.Scheme()); err != nil {
+			return legacyerrors.Wrapf(err, "error setting owner of connection secret %s/%s", component.GetNamespace(), name)
+		}
+		if err := clnt.Create(ctx, secret, client.FieldOwner(fieldOwner)); err != nil {
+			return legacyerrors.Wrapf(err, "error creating connection secret %s/%s", component.GetNamespace(), name)
+		}
+		return nil
+	}
+	if !metav1.IsControlledBy(secret, component) {
+		return fmt.Errorf("connection secret %s/%s exists but is not owned by the component", component.GetNamespace(), name)
+	}
+	if maps.EqualFunc(secret.Data, data, bytes.Equal) {
+		return nil
+	}
+	secret.Data = data
+	if err := clnt.Update(ctx, secret, client.FieldOwner(fieldOwner)); err != nil {
+		return legacyerrors.Wrapf(err, "error updating connection secret %s/%s", component.GetNamespace(), name)
+	}
+	return nil
+}
+
+// Delete the given connection secret of a component (if existing, and owned by the component); this is used to clean up
+// connection secrets which were written before, but are no longer used because the component's connection secret name changed.
+func deleteConnectionSecret(ctx context.Context, clnt client.Client, component Component, name string) error {
+	secret := &corev1.Secret{}
+	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: component.GetNamespace(), Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return legacyerrors.Wrapf(err, "error reading connection secret %s/%s", component.GetNamespace(), name)
+	}
+	if !metav1.IsControlledBy(secret, component) {
+		return nil
+	}
+	if err := clnt.Delete(ctx, secret, client.Preconditions{UID: &secret.UID}); client.IgnoreNotFound(err) != nil {
+		return legacyerrors.Wrapf(err, "error deleting connection secret %s/%s", component.GetNamespace(), name)
+	}
+	return nil
+}
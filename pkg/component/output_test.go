@@ -0,0 +1,153 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = ginkgo.Describe("testing: output.go", func() {
+	var ctx context.Context
+	var clnt client.Client
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		clnt = newTestClient(
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: "10.0.0.1",
+					Ports:     []corev1.ServicePort{{Port: 5432}},
+				},
+			},
+			newTestSecret("default", "db-credentials", nil),
+		)
+	})
+
+	ginkgo.It("should parse outputs from annotations", func() {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "db",
+				Annotations: map[string]string{
+					"test/outputs":           `{"host": "{.spec.clusterIP}", "port": "{.spec.ports[0].port}"}`,
+					"test/sensitive-outputs": `password: "{.data.key}"`,
+				},
+			},
+		}
+		outputs, err := getOutputsFromAnnotations("test", []client.Object{service})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(ConsistOf(
+			manifests.Output{Name: "host", Object: service, Path: "{.spec.clusterIP}"},
+			manifests.Output{Name: "port", Object: service, Path: "{.spec.ports[0].port}"},
+			manifests.Output{Name: "password", Object: service, Path: "{.data.key}", Sensitive: true},
+		))
+	})
+
+	ginkgo.It("should fail on invalid output annotations", func() {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "db",
+				Annotations: map[string]string{"test/outputs": `[invalid`},
+			},
+		}
+		_, err := getOutputsFromAnnotations("test", []client.Object{service})
+		Expect(err).To(HaveOccurred())
+	})
+
+	ginkgo.It("should collect outputs from live objects", func() {
+		outputs := []manifests.Output{
+			{Name: "host", Object: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db"}}, Path: "{.spec.clusterIP}"},
+			{Name: "port", Object: types.ObjectKey(&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db"}}), Path: "{.spec.ports[0].port}"},
+			{Name: "password", Object: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-credentials"}}, Path: "{.data.key}", Sensitive: true},
+		}
+		publicOutputs, allOutputs, err := collectOutputs(ctx, clnt, "default", outputs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(publicOutputs).To(Equal(map[string]string{
+			"host": "10.0.0.1",
+			"port": "5432",
+		}))
+		Expect(allOutputs).To(Equal(map[string][]byte{
+			"host":     []byte("10.0.0.1"),
+			"port":     []byte("5432"),
+			"password": []byte("db-credentials"),
+		}))
+	})
+
+	ginkgo.It("should decode secret data regardless of the used template", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-credentials"}}
+		outputs := []manifests.Output{
+			{Name: "name", Object: secret, Path: "{.metadata.name}"},
+			{Name: "url", Object: secret, Path: "user:{.data.key}@db"},
+		}
+		publicOutputs, _, err := collectOutputs(ctx, clnt, "default", outputs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(publicOutputs).To(Equal(map[string]string{
+			"name": "db-credentials",
+			"url":  "user:db-credentials@db",
+		}))
+	})
+
+	ginkgo.It("should fail if an output cannot be evaluated", func() {
+		outputs := []manifests.Output{
+			{Name: "host", Object: &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db"}}, Path: "{.status.loadBalancer.ingress[0].ip}"},
+		}
+		_, _, err := collectOutputs(ctx, clnt, "default", outputs)
+		Expect(err).To(HaveOccurred())
+	})
+
+	ginkgo.It("should create and update the connection secret", func() {
+		component := newTestComponent("default", "test")
+		component.UID = "uid"
+		Expect(writeConnectionSecret(ctx, clnt, component, "test-connection", map[string][]byte{"host": []byte("a")}, "test")).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(clnt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-connection"}, secret)).To(Succeed())
+		Expect(secret.Data).To(Equal(map[string][]byte{"host": []byte("a")}))
+		Expect(metav1.IsControlledBy(secret, component)).To(BeTrue())
+		Expect(writeConnectionSecret(ctx, clnt, component, "test-connection", map[string][]byte{"host": []byte("b")}, "test")).To(Succeed())
+		Expect(clnt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-connection"}, secret)).To(Succeed())
+		Expect(secret.Data).To(Equal(map[string][]byte{"host": []byte("b")}))
+	})
+
+	ginkgo.It("should delete obsolete connection secrets owned by the component", func() {
+		component := newTestComponent("default", "test")
+		component.UID = "uid"
+		Expect(writeConnectionSecret(ctx, clnt, component, "test-connection", map[string][]byte{"host": []byte("a")}, "test")).To(Succeed())
+		Expect(deleteConnectionSecret(ctx, clnt, component, "test-connection")).To(Succeed())
+		Expect(clnt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-connection"}, &corev1.Secret{})).NotTo(Succeed())
+		Expect(deleteConnectionSecret(ctx, clnt, component, "test-connection")).To(Succeed())
+		Expect(deleteConnectionSecret(ctx, clnt, component, "db-credentials")).To(Succeed())
+		Expect(clnt.Get(ctx, client.ObjectKey{Namespace: "default", Name: "db-credentials"}, &corev1.Secret{})).To(Succeed())
+	})
+
+	ginkgo.It("should retain the output and target settings of the component when copying it", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.ConnectionSecretName = "test-connection"
+		component.Spec.Targets = []TargetSpec{{Name: "a"}}
+		Expect(clnt.Create(ctx, component)).To(Succeed())
+		storedComponent := &testComponent{}
+		Expect(clnt.Get(ctx, client.ObjectKeyFromObject(component), storedComponent)).To(Succeed())
+		Expect(storedComponent.Spec).To(Equal(component.Spec))
+		copiedComponent := component.DeepCopyObject().(*testComponent)
+		copiedComponent.Spec.Targets[0].Name = "b"
+		Expect(component.Spec.Targets[0].Name).To(Equal("a"))
+	})
+
+	ginkgo.It("should not overwrite foreign connection secrets", func() {
+		component := newTestComponent("default", "test")
+		component.UID = "uid"
+		Expect(writeConnectionSecret(ctx, clnt, component, "db-credentials", map[string][]byte{"host": []byte("a")}, "test")).NotTo(Succeed())
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -17,8 +18,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gobwas/glob"
 	legacyerrors "github.com/pkg/errors"
 	"github.com/sap/go-generics/slices"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -26,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -64,21 +70,38 @@ import (
 // TODO: when calling backoff.Next() we could use something more specific than 'req' as key (maybe req+componentDigest or req+processingSince)
 
 const (
-	ReadyConditionReasonNew                = "FirstSeen"
-	ReadyConditionReasonRetrying           = "Retrying"
-	ReadyConditionReasonRestarting         = "Restarting"
-	ReadyConditionReasonProcessing         = "Processing"
-	ReadyConditionReasonReady              = "Ready"
-	ReadyConditionReasonError              = "Error"
-	ReadyConditionReasonTimeout            = "Timeout"
-	ReadyConditionReasonSuspended          = "Suspended"
-	ReadyConditionReasonDeletionRetrying   = "DeletionRetrying"
-	ReadyConditionReasonDeletionBlocked    = "DeletionBlocked"
-	ReadyConditionReasonDeletionProcessing = "DeletionProcessing"
+	ReadyConditionReasonNew                      = "FirstSeen"
+	ReadyConditionReasonRetrying                 = "Retrying"
+	ReadyConditionReasonRestarting               = "Restarting"
+	ReadyConditionReasonProcessing               = "Processing"
+	ReadyConditionReasonReady                    = "Ready"
+	ReadyConditionReasonError                    = "Error"
+	ReadyConditionReasonTimeout                  = "Timeout"
+	ReadyConditionReasonSuspended                = "Suspended"
+	ReadyConditionReasonDeletionRetrying         = "DeletionRetrying"
+	ReadyConditionReasonDeletionBlocked          = "DeletionBlocked"
+	ReadyConditionReasonDeletionProcessing       = "DeletionProcessing"
+	ReadyConditionReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
+	ReadyConditionReasonApprovalPending          = "ApprovalPending"
+	ReadyConditionReasonStalled                  = "Stalled"
+	ReadyConditionReasonDeleteHookFailed         = "DeleteHookFailed"
+
+	TestedConditionReasonRunning   = "Running"
+	TestedConditionReasonSucceeded = "Succeeded"
+	TestedConditionReasonFailed    = "Failed"
 
 	triggerBufferSize = 1024
 
 	defaultReapplyInterval = 60 * time.Minute
+
+	defaultClientTTL = 15 * time.Minute
+
+	minMaxBackoff = 1 * time.Second
+	maxMaxBackoff = 1 * time.Hour
+
+	tracerName = "github.com/sap/component-operator-runtime/pkg/component"
+
+	serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 // TODO: should we pass cluster.Client to hooks instead of just client.Client (or, in the other direction, just client.Reader)?
@@ -127,6 +150,12 @@ type ReconcilerOptions struct {
 	MissingNamespacesPolicy *reconciler.MissingNamespacesPolicy
 	// Interval after which an object will be force-reapplied, even if it seems to be synced.
 	ReapplyInterval *time.Duration
+	// How to analyze the state of the dependent objects.
+	// If unspecified, an optimized kstatus based implementation is used.
+	StatusAnalyzer status.StatusAnalyzer
+	// Analyzers for specific types of dependent objects (overriding StatusAnalyzer for these types).
+	// If the version of a key is empty, the according analyzer is used for all versions of the type.
+	StatusAnalyzers map[schema.GroupVersionKind]status.StatusAnalyzer
 	// SchemeBuilder allows to define additional schemes to be made available in the
 	// target client.
 	SchemeBuilder types.SchemeBuilder
@@ -134,6 +163,36 @@ type ReconcilerOptions struct {
 	// The returned client is used by the reconciler to manage the component instances.
 	// Its scheme therefore must recognize the component type.
 	NewClient NewClientFunc
+	// Namespaces (besides the component's own namespace) from which configmap or secret references may be loaded.
+	// Entries may be glob patterns, such as "shared-*", or "*". If a reference points to a namespace not matching
+	// any of these patterns, then the referenced object must grant access by setting the annotation
+	// <reconciler-name>/reference-grant to a comma-separated list of namespaces containing the component's namespace (or to "*").
+	ReferenceNamespaces []string
+	// Rate limiter used to calculate the requeue delays while the component is processing (or deleting, suspended, and so on).
+	// If unspecified, a default rate limiter is used, which backs off to a maximum delay of 10 seconds.
+	// Components may override the maximum delay (within 1 second and 1 hour) by implementing the BackoffConfiguration interface;
+	// if a custom rate limiter is specified, that override can only lower the delays returned by the rate limiter.
+	BackoffRateLimiter workqueue.RateLimiter
+	// Rate limiter of the controller's workqueue (affecting in particular requeues after errors).
+	// If unspecified, the controller-runtime default is used. Only effective if the reconciler is set up through SetupWithManager().
+	QueueRateLimiter workqueue.TypedRateLimiter[reconcile.Request]
+	// Which tracer provider to use to create spans for the reconciliation of components (including reference resolution,
+	// manifest generation, and the apply/delete waves of the dependent objects).
+	// If unspecified, the global tracer provider is used.
+	TracerProvider trace.TracerProvider
+	// Duration after which cached clients (for kubeconfigs supplied by components, or for impersonation) are dropped if unused.
+	// If unspecified, 15 minutes is assumed.
+	ClientTTL *time.Duration
+	// Whether kubeconfigs supplied by components may use exec credential plugins.
+	// Note that such plugins run arbitrary commands in the operator's container; so this should only be enabled if kubeconfigs are trusted.
+	// If unspecified, false is assumed.
+	EnableExecCredentialPlugins *bool
+	// Circuit breaker for components which fail persistently with the same error.
+	// If unspecified, failing components are retried (with backoff) forever.
+	CircuitBreaker *CircuitBreakerOptions
+	// Distribution of components across multiple replicas of the operator.
+	// If unspecified, all components are reconciled by this reconciler (which usually runs in the elected leader only).
+	Sharding *ShardingOptions
 }
 
 // Reconciler provides the implementation of controller-runtime's Reconciler interface, for a given Component type T.
@@ -145,21 +204,28 @@ type Reconciler[T Component] struct {
 	// TODO: client could be just a client.Client
 	client cluster.Client
 	// TODO: hookClient could be just a client.Client or even client.Reader
-	hookClient         cluster.Client
-	eventRecorder      events.DeduplicatingRecorder
-	resourceGenerator  manifests.Generator
-	statusAnalyzer     status.StatusAnalyzer
-	options            ReconcilerOptions
-	clients            *clientfactory.ClientFactory
-	backoff            *backoff.Backoff
-	postReadHooks      []HookFunc[T]
-	preReconcileHooks  []HookFunc[T]
-	postReconcileHooks []HookFunc[T]
-	preDeleteHooks     []HookFunc[T]
-	postDeleteHooks    []HookFunc[T]
-	triggerCh          chan event.TypedGenericEvent[apitypes.NamespacedName]
-	setupMutex         sync.Mutex
-	setupComplete      bool
+	hookClient          cluster.Client
+	eventRecorder       events.DeduplicatingRecorder
+	resourceGenerator   manifests.Generator
+	statusAnalyzer      status.StatusAnalyzer
+	tracer              trace.Tracer
+	sharder             sharder
+	options             ReconcilerOptions
+	referenceNamespaces []glob.Glob
+	clients             *clientfactory.ClientFactory
+	backoff             *backoff.Backoff
+	// kubeconfigs of the targets of multi-target components (by component key and target name); remembered such that
+	// dependent objects can be deleted from targets after these were removed from the component
+	targetKubeConfigs     map[apitypes.NamespacedName]map[string][]byte
+	targetKubeConfigMutex sync.Mutex
+	postReadHooks         []HookFunc[T]
+	preReconcileHooks     []HookFunc[T]
+	postReconcileHooks    []HookFunc[T]
+	preDeleteHooks        []HookFunc[T]
+	postDeleteHooks       []HookFunc[T]
+	triggerCh             chan event.TypedGenericEvent[apitypes.NamespacedName]
+	setupMutex            sync.Mutex
+	setupComplete         bool
 }
 
 // Create a new Reconciler.
@@ -191,16 +257,57 @@ func NewReconciler[T Component](name string, resourceGenerator manifests.Generat
 	if options.ReapplyInterval == nil {
 		options.ReapplyInterval = new(defaultReapplyInterval)
 	}
-
+	if options.StatusAnalyzer == nil {
+		options.StatusAnalyzer = status.NewStatusAnalyzer(name)
+	}
+	if len(options.StatusAnalyzers) > 0 {
+		registry := status.NewStatusAnalyzerRegistry(options.StatusAnalyzer)
+		for gvk, analyzer := range options.StatusAnalyzers {
+			registry.Register(gvk, analyzer)
+		}
+		options.StatusAnalyzer = registry
+	}
+	if options.BackoffRateLimiter == nil {
+		options.BackoffRateLimiter = backoff.NewDefaultRateLimiter(10 * time.Second)
+	}
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
+	if options.CircuitBreaker != nil {
+		circuitBreaker := *options.CircuitBreaker
+		if circuitBreaker.Threshold <= 0 {
+			circuitBreaker.Threshold = defaultCircuitBreakerThreshold
+		}
+		if circuitBreaker.CoolDown == 0 {
+			circuitBreaker.CoolDown = defaultCircuitBreakerCoolDown
+		}
+		options.CircuitBreaker = &circuitBreaker
+	}
+	if options.ClientTTL == nil {
+		options.ClientTTL = new(defaultClientTTL)
+	}
+	if options.EnableExecCredentialPlugins == nil {
+		options.EnableExecCredentialPlugins = new(false)
+	}
+	if options.Sharding != nil {
+		sharding := *options.Sharding
+		if sharding.LeaseDuration == 0 {
+			sharding.LeaseDuration = defaultShardLeaseDuration
+		}
+		if sharding.RenewInterval == 0 {
+			sharding.RenewInterval = defaultShardRenewInterval
+		}
+		options.Sharding = &sharding
+	}
 	return &Reconciler[T]{
 		name:              name,
 		resourceGenerator: resourceGenerator,
-		// TODO: make statusAnalyzer specifiable via options?
-		statusAnalyzer: status.NewStatusAnalyzer(name),
-		options:        options,
-		// TODO: make backoff configurable via options?
-		backoff:   backoff.NewBackoff(backoff.NewDefaultRateLimiter(10 * time.Second)),
-		triggerCh: make(chan event.TypedGenericEvent[apitypes.NamespacedName], triggerBufferSize),
+		statusAnalyzer:    options.StatusAnalyzer,
+		tracer:            options.TracerProvider.Tracer(tracerName),
+		options:           options,
+		backoff:           backoff.NewBackoff(options.BackoffRateLimiter),
+		targetKubeConfigs: make(map[apitypes.NamespacedName]map[string][]byte),
+		triggerCh:         make(chan event.TypedGenericEvent[apitypes.NamespacedName], triggerBufferSize),
 	}
 }
 
@@ -218,6 +325,14 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 
 	metrics.Reconciles.WithLabelValues(r.controllerName).Inc()
 
+	// note: the span is passed through ctx to hooks and generators, and ends with the (final) error returned by this method
+	ctx, span := r.tracer.Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("component.kind", r.groupVersionKind.Kind),
+		attribute.String("component.namespace", req.Namespace),
+		attribute.String("component.name", req.Name),
+	))
+	defer func() { util.EndSpan(span, err) }()
+
 	now := metav1.Now()
 
 	// fetch reconciled component
@@ -225,11 +340,24 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 	if err := r.client.Get(ctx, req.NamespacedName, component); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.V(1).Info("not found; ignoring")
+			r.targetKubeConfigMutex.Lock()
+			delete(r.targetKubeConfigs, req.NamespacedName)
+			r.targetKubeConfigMutex.Unlock()
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, legacyerrors.Wrap(err, "unexpected get error")
 	}
 	component.GetObjectKind().SetGroupVersionKind(r.groupVersionKind)
+	if !r.isResponsible(component) {
+		log.V(1).Info("served by another shard; ignoring")
+		return ctrl.Result{}, nil
+	}
+	defer func() {
+		span.SetAttributes(
+			attribute.String("component.state", string(component.GetStatus().State)),
+			attribute.Int64("component.revision", component.GetStatus().Revision),
+		)
+	}()
 	// componentDigest is populated after setting up the status handler, right before the post-read hook phase
 	componentDigest := ""
 
@@ -256,12 +384,17 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 		timeout = requeueInterval
 	}
 
+	// get backoff (which is specific to the component's maximum backoff, if configured)
+	componentBackoff := r.getBackoffForComponent(component)
+
 	// convenience accessors
 	status := component.GetStatus()
 	savedStatus := status.DeepCopy()
 
 	// always attempt to update the status
 	skipStatusUpdate := false
+	// set if the component was moved to another shard while being reconciled; then the component is left alone entirely
+	fenced := false
 	defer func() {
 		if r := recover(); r != nil {
 			log.Error(fmt.Errorf("panic occurred during reconcile"), "panic", r)
@@ -269,13 +402,17 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			panic(r)
 		}
 
+		if fenced {
+			return
+		}
+
 		status.ObservedGeneration = component.GetGeneration()
 
 		if status.State == StateReady || err != nil {
 			// clear backoff if state is ready (obviously) or if there is an error;
 			// even is the error is a RetriableError which will be turned into a non-error;
 			// this is correct, because in that case, the RequeueAfter will be determined through the RetriableError
-			r.backoff.Forget(req)
+			componentBackoff.Forget(req)
 		}
 
 		haveTimeout := status.ProcessingSince != nil && now.Sub(status.ProcessingSince.Time) >= timeout
@@ -303,7 +440,7 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 				if haveTimeout {
 					status.SetState(StateError, ReadyConditionReasonTimeout, "Reconcilation of dependent resources timed out")
 				}
-			case StatePending, StateError:
+			case StatePending, StateError, StateStalled:
 				// nothing to be done (see the remark before the switch above)
 			case StateDeletionPending, StateDeleting:
 				// because these states can only occur if deletionTimestamp is not zero
@@ -314,23 +451,53 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			}
 		}
 
+		// note: err might be cleared below (e.g. if a retry delay was specified), so remember whether the reconciliation failed (for the circuit breaker)
+		failed := err != nil
+		emitEvent := true
 		if err != nil {
-			// convert retriable errors into non-errors (Pending or DeletionPending state), and return specified or default backoff
+			// reflect reconcile errors in the status as requested by the error (returning a non-error if a retry delay was specified);
+			// convert retriable errors into non-errors (Pending or DeletionPending state), and return specified or default backoff;
+			// in both cases, the custom reason and message of the error (if specified) take precedence over the defaults
+			reconcileError := &types.ReconcileError{}
 			retriableError := &types.RetriableError{}
-			if errors.As(err, retriableError) {
+			if errors.As(err, reconcileError) {
+				emitEvent = reconcileError.EmitEvent()
+				message := reconcileError.Message()
+				if message == "" {
+					message = capitalize(reconcileError.Error())
+				}
+				reason := reconcileError.Reason()
+				retryAfter := reconcileError.RetryAfter()
+				switch reconcileError.State() {
+				case types.ErrorStatePending:
+					if retryAfter == nil || *retryAfter == 0 {
+						retryAfter = &retryInterval
+					}
+					if component.GetDeletionTimestamp().IsZero() {
+						status.SetState(StatePending, defaultReason(reason, ReadyConditionReasonRetrying, haveTimeout), message)
+					} else {
+						status.SetState(StateDeletionPending, defaultReason(reason, ReadyConditionReasonDeletionRetrying, false), message)
+					}
+				default:
+					status.SetState(StateError, defaultReason(reason, ReadyConditionReasonError, component.GetDeletionTimestamp().IsZero() && haveTimeout), message)
+				}
+				if retryAfter != nil && *retryAfter > 0 {
+					result = ctrl.Result{RequeueAfter: *retryAfter}
+					err = nil
+				}
+			} else if errors.As(err, retriableError) {
 				retryAfter := retriableError.RetryAfter()
 				if retryAfter == nil || *retryAfter == 0 {
 					retryAfter = &retryInterval
 				}
-				// TODO: allow RetriableError to provide custom reason and message
+				message := retriableError.Message()
+				if message == "" {
+					message = capitalize(retriableError.Error())
+				}
 				if component.GetDeletionTimestamp().IsZero() {
-					if haveTimeout {
-						status.SetState(StatePending, ReadyConditionReasonTimeout, capitalize(retriableError.Error()))
-					} else {
-						status.SetState(StatePending, ReadyConditionReasonRetrying, capitalize(retriableError.Error()))
-					}
+					status.SetState(StatePending, defaultReason(retriableError.Reason(), ReadyConditionReasonRetrying, haveTimeout), message)
 				} else {
-					status.SetState(StateDeletionPending, ReadyConditionReasonDeletionRetrying, capitalize(retriableError.Error()))
+					status.SetState(StateDeletionPending, defaultReason(retriableError.Reason(), ReadyConditionReasonDeletionRetrying, false), message)
 				}
 				result = ctrl.Result{RequeueAfter: *retryAfter}
 				err = nil
@@ -343,6 +510,20 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			}
 		}
 
+		// open the circuit breaker (that is, stall the component) if it failed too many times in a row with the same error;
+		// note: only failures resulting in Error state are counted (components in Pending state are waiting, not failing);
+		// note: components which are already stalled (and were therefore skipped) are not considered here
+		if r.options.CircuitBreaker != nil && component.GetDeletionTimestamp().IsZero() && status.State != StateStalled {
+			if recordFailure(status, savedStatus, failed, r.options.CircuitBreaker.Threshold) {
+				log.V(1).Info("stalling component after consecutive failures", "failures", status.ConsecutiveFailures)
+				_, _, message := status.GetState()
+				status.SetState(StateStalled, ReadyConditionReasonStalled, message)
+				status.StalledSince = &now
+				result = ctrl.Result{RequeueAfter: r.options.CircuitBreaker.CoolDown}
+				err = nil
+			}
+		}
+
 		if result.RequeueAfter > 0 {
 			// add jitter of 1-5 percent to RequeueAfter
 			addJitter(&result.RequeueAfter, 1, 5)
@@ -380,13 +561,17 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			metrics.ComponentState.WithLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
 				component.GetNamespace(), component.GetName(), string(StateError)).
 				Set(float64(boolToInt(state == StateError)))
+			metrics.ComponentState.WithLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
+				component.GetNamespace(), component.GetName(), string(StateStalled)).
+				Set(float64(boolToInt(state == StateStalled)))
 			metrics.Dependents.WithLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
 				component.GetNamespace(), component.GetName()).
 				Set(float64(len(status.Inventory)))
 			metrics.UnreadyDependents.WithLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
 				component.GetNamespace(), component.GetName()).
 				Set(float64(slices.Count(status.Inventory, func(item *reconciler.InventoryItem) bool {
-					return item.Phase != reconciler.PhaseReady && item.Phase != reconciler.PhaseCompleted
+					// note: test and hook objects do not take part in the readiness of the component
+					return item.IsRegular() && item.Phase != reconciler.PhaseReady && item.Phase != reconciler.PhaseCompleted
 				})))
 		} else {
 			metrics.ComponentState.DeleteLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
@@ -401,6 +586,8 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 				component.GetNamespace(), component.GetName(), string(StateDeleting))
 			metrics.ComponentState.DeleteLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
 				component.GetNamespace(), component.GetName(), string(StateError))
+			metrics.ComponentState.DeleteLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
+				component.GetNamespace(), component.GetName(), string(StateStalled))
 			metrics.Dependents.DeleteLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
 				component.GetNamespace(), component.GetName())
 			metrics.UnreadyDependents.DeleteLabelValues(r.controllerName, component.GetObjectKind().GroupVersionKind().Group, component.GetObjectKind().GroupVersionKind().Kind,
@@ -423,10 +610,13 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 		// TODO: sending events may block a little while (some seconds), in particular if enhanced recorders are installed through options.NewClient(),
 		// such as the flux notfication recorder; should we therefore send the events asynchronously, or start synchronously and continue asynchronous
 		// after a little while?
-		if state == StateError {
-			r.eventRecorder.AnnotatedEventf(component, eventAnnotations, corev1.EventTypeWarning, reason, "%s", message)
-		} else {
-			r.eventRecorder.AnnotatedEventf(component, eventAnnotations, corev1.EventTypeNormal, reason, "%s", message)
+		// note: emitting the event may be suppressed by the returned error
+		if emitEvent {
+			if state == StateError || state == StateStalled {
+				r.eventRecorder.AnnotatedEventf(component, eventAnnotations, corev1.EventTypeWarning, reason, "%s", message)
+			} else {
+				r.eventRecorder.AnnotatedEventf(component, eventAnnotations, corev1.EventTypeNormal, reason, "%s", message)
+			}
 		}
 
 		if skipStatusUpdate {
@@ -452,19 +642,50 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 		return ctrl.Result{RequeueAfter: time.Millisecond}, nil
 	}
 
+	// skip stalled components until the cool-down is over, or their spec changes, or a retry is requested by annotation
+	if r.options.CircuitBreaker != nil && component.GetDeletionTimestamp().IsZero() {
+		retry := component.GetAnnotations()[r.name+"/"+types.AnnotationKeySuffixRetry]
+		if status.State == StateStalled {
+			if until, ok := remainsStalled(status, component.GetGeneration(), retry, now, r.options.CircuitBreaker.CoolDown); ok {
+				log.V(1).Info("component is stalled; skipping", "until", until)
+				return ctrl.Result{RequeueAfter: until.Sub(now.Time)}, nil
+			}
+			// note: if the component is released just because the cool-down is over, then the failure count is preserved;
+			// that means, the component will be stalled again if the next attempt fails with the same error
+			if component.GetGeneration() != status.ObservedGeneration || retry != status.LastHandledRetry {
+				status.ConsecutiveFailures = 0
+			}
+			status.StalledSince = nil
+			status.SetState(StatePending, ReadyConditionReasonRetrying, "Resuming reconciliation of stalled component")
+		}
+		status.LastHandledRetry = retry
+	}
+
 	if component.GetDeletionTimestamp().IsZero() {
 		if suspensionConfiguration, ok := assertSuspensionConfiguration(component); ok && suspensionConfiguration.IsSuspended() {
 			status.SetState(StatePending, ReadyConditionReasonSuspended, "Reconciliation is suspended")
-			return ctrl.Result{RequeueAfter: r.backoff.Next(req, ReadyConditionReasonSuspended)}, nil
+			return ctrl.Result{RequeueAfter: componentBackoff.Next(req, ReadyConditionReasonSuspended)}, nil
 		}
 	}
 
 	// resolve references
-	componentDigest, err = resolveReferences(ctx, r.client, r.hookClient, component)
+	// note: references tagged with cluster:"target" are loaded through the target client, which is created lazily
+	// (after all local references, and therefore in particular the kubeconfig reference, have been loaded)
+	getTargetClient := func() (client.Client, error) {
+		return r.getClientForComponent(component)
+	}
+	resolveCtx, resolveSpan := r.tracer.Start(ctx, "ResolveReferences")
+	componentDigest, err = resolveReferences(resolveCtx, r.client, r.hookClient, getTargetClient, component, r.referenceNamespaces, r.name)
+	util.EndSpan(resolveSpan, err)
 	if err != nil {
 		return ctrl.Result{}, legacyerrors.Wrap(err, "error resolving references")
 	}
 
+	// enhance ctx with a logger and an event recorder tailored to the component; this context is passed to hooks and generators
+	// note: the original context is kept, because the logger is refreshed below (after the revision of the component was incremented)
+	reconcileCtx := ctx
+	ctx = r.newContextForComponent(reconcileCtx, component, componentDigest)
+
 	if component.GetDeletionTimestamp().IsZero() {
 		// start a new processing timeout cycle if the component digest changes; note that (other than status.ProcessingSince)
 		// status.ProcessingDigest is never cleared
@@ -474,7 +695,7 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 		} else if componentDigest != status.ProcessingDigest {
 			status.ProcessingSince = nil
 			status.ProcessingDigest = componentDigest
-			r.backoff.Forget(req)
+			componentBackoff.Forget(req)
 			status.SetState(StateProcessing, ReadyConditionReasonRestarting, "Restarting processing due to component changes")
 			return ctrl.Result{RequeueAfter: time.Millisecond}, nil
 		}
@@ -485,7 +706,6 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 
 	// run post-read hooks
 	// note: it's important that this happens after deferring the status handler
-	// TODO: enhance ctx with tailored logger and event recorder
 	// TODO: should ctx enhanced with componentDigest?
 	hookCtx := NewContext(ctx).
 		WithReconcilerName(r.name)
@@ -504,9 +724,10 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 	if err != nil {
 		return ctrl.Result{}, legacyerrors.Wrap(err, "error getting client for component")
 	}
-	targetOptions := r.getOptionsForComponent(component)
-	target := newReconcileTarget[T](r.name, r.id, localClient, targetClient, r.resourceGenerator, targetOptions)
-	// TODO: enhance ctx with tailored logger and event recorder
+	target, err := r.getTargetForComponent(ctx, component, localClient, targetClient, false)
+	if err != nil {
+		return ctrl.Result{}, legacyerrors.Wrap(err, "error getting targets for component")
+	}
 	// TODO: should ctx enhanced with componentDigest?
 	hookCtx = NewContext(ctx).
 		WithReconcilerName(r.name).
@@ -528,15 +749,66 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			return ctrl.Result{RequeueAfter: time.Millisecond}, nil
 		}
 
+		// hold changes (and, depending on the policy, drift correction) outside of maintenance windows
+		if maintenanceWindowConfiguration, ok := assertMaintenanceWindowConfiguration(component); ok {
+			active, next, err := checkMaintenanceWindows(maintenanceWindowConfiguration.GetMaintenanceWindows(), now.Time)
+			if err != nil {
+				return ctrl.Result{}, legacyerrors.Wrap(err, "error checking maintenance windows")
+			}
+			if !active {
+				if status.ProcessingDigest != status.LastProcessingDigest {
+					log.V(1).Info("holding component changes until next maintenance window", "nextMaintenanceWindow", next)
+					status.SetState(StatePending, ReadyConditionReasonOutsideMaintenanceWindow, fmt.Sprintf("Changes will be applied in the next maintenance window (starting at %s)", next.UTC().Format(time.RFC3339)))
+					return ctrl.Result{RequeueAfter: next.Sub(now.Time)}, nil
+				}
+				if status.State == StateReady && maintenanceWindowConfiguration.GetMaintenanceWindowDriftCorrectionPolicy() == MaintenanceWindowPolicyHold {
+					log.V(1).Info("skipping drift correction until next maintenance window", "nextMaintenanceWindow", next)
+					status.SetState(StateReady, ReadyConditionReasonReady, "Dependent resources successfully reconciled")
+					return ctrl.Result{RequeueAfter: min(requeueInterval, next.Sub(now.Time))}, nil
+				}
+			}
+		}
+
 		// TODO: this is temporarily needed until the revision is adopted by all consumers and rolled out completely
 		// otherwise, existing components would have revision == 1 which might lead to problems with helm generator
 		if status.Revision == 0 && status.LastAppliedAt != nil {
 			status.Revision = 1
 		}
 
+		// hold changes until the plan is approved (if so configured)
+		if approvalConfiguration, ok := assertApprovalConfiguration(component); ok && approvalConfiguration.IsApprovalRequired() {
+			if status.ProcessingDigest != status.LastProcessingDigest {
+				plan, err := target.Plan(ctx, component, componentDigest, status.Revision+1)
+				if err != nil {
+					return ctrl.Result{}, legacyerrors.Wrap(err, "error calculating plan")
+				}
+				status.Plan = plan
+				if !plan.isEmpty() && component.GetAnnotations()[r.name+"/"+types.AnnotationKeySuffixApprovedPlan] != plan.Digest {
+					log.V(1).Info("waiting for approval of plan", "plan", plan.Digest)
+					status.SetState(StatePending, ReadyConditionReasonApprovalPending, fmt.Sprintf("Waiting for approval of plan %s", plan.Digest))
+					return ctrl.Result{RequeueAfter: requeueInterval}, nil
+				}
+			}
+		} else {
+			status.Plan = nil
+		}
+
 		if status.ProcessingDigest != status.LastProcessingDigest {
 			status.Revision += 1
 			status.LastProcessingDigest = status.ProcessingDigest
+			ctx = r.newContextForComponent(reconcileCtx, component, componentDigest)
+			hookCtx = NewContext(ctx).
+				WithReconcilerName(r.name).
+				WithLocalClient(localClient).
+				WithClient(targetClient)
+		}
+
+		// check again whether this replica is still responsible for the component (it might have been moved to another shard
+		// while being reconciled); otherwise, the dependent objects could be applied by two replicas concurrently
+		if !r.isResponsible(component) {
+			log.V(1).Info("moved to another shard; aborting reconciliation")
+			fenced = true
+			return ctrl.Result{}, nil
 		}
 
 		log.V(2).Info("reconciling dependent resources")
@@ -551,28 +823,94 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			return ctrl.Result{}, legacyerrors.Wrap(err, "error reconciling dependent resources")
 		}
 		if ok {
+			outputs, connectionSecretData, err := target.CollectOutputs(ctx)
+			if err != nil {
+				return ctrl.Result{}, legacyerrors.Wrap(err, "error collecting outputs")
+			}
+			if len(outputs) > 0 {
+				status.Outputs = outputs
+			} else {
+				status.Outputs = nil
+			}
+			status.Notes = target.GetNotes()
+			connectionSecretName := ""
+			if outputConfiguration, ok := assertOutputConfiguration(component); ok {
+				connectionSecretName = outputConfiguration.GetConnectionSecretName()
+			}
+			if connectionSecretName != "" {
+				if err := writeConnectionSecret(ctx, r.client, component, connectionSecretName, connectionSecretData, *r.options.FieldOwner); err != nil {
+					return ctrl.Result{}, legacyerrors.Wrap(err, "error writing connection secret")
+				}
+			}
+			if status.ConnectionSecretName != "" && status.ConnectionSecretName != connectionSecretName {
+				if err := deleteConnectionSecret(ctx, r.client, component, status.ConnectionSecretName); err != nil {
+					return ctrl.Result{}, legacyerrors.Wrap(err, "error deleting obsolete connection secret")
+				}
+			}
+			status.ConnectionSecretName = connectionSecretName
 			for hookOrder, hook := range r.postReconcileHooks {
 				if err := hook(hookCtx, r.hookClient, component); err != nil {
 					return ctrl.Result{}, legacyerrors.Wrapf(err, "error running post-reconcile hook (%d)", hookOrder)
 				}
 			}
 			log.V(1).Info("all dependent resources successfully reconciled")
+			// the plan (if any) is applied now; clear it, such that it does not appear to be awaiting approval
+			status.Plan = nil
 			status.AppliedGeneration = component.GetGeneration()
 			status.LastAppliedAt = &now
 			status.SetState(StateReady, ReadyConditionReasonReady, "Dependent resources successfully reconciled")
+			if testConfiguration, ok := assertTestConfiguration(component); ok && testConfiguration.IsTestEnabled() {
+				tested, msg, err := target.Test(ctx, component, componentDigest)
+				if err != nil {
+					log.V(1).Info("error while running tests")
+					return ctrl.Result{}, legacyerrors.Wrap(err, "error running tests")
+				}
+				switch {
+				case !tested:
+					log.V(1).Info("not all tests completed")
+					status.setTested(ConditionUnknown, TestedConditionReasonRunning, "Tests are running")
+					return ctrl.Result{RequeueAfter: componentBackoff.Next(req, TestedConditionReasonRunning)}, nil
+				case msg != "":
+					log.V(1).Info("tests failed")
+					status.setTested(ConditionFalse, TestedConditionReasonFailed, capitalize(msg))
+				default:
+					log.V(1).Info("all tests succeeded")
+					status.setTested(ConditionTrue, TestedConditionReasonSucceeded, "All tests succeeded")
+				}
+			} else {
+				status.removeCondition(ConditionTypeTested)
+			}
 			return ctrl.Result{RequeueAfter: requeueInterval}, nil
 		} else {
 			log.V(1).Info("not all dependent resources successfully reconciled")
-			if !reflect.DeepEqual(status.Inventory, savedStatus.Inventory) {
-				r.backoff.Forget(req)
+			if !reflect.DeepEqual(status.Inventory, savedStatus.Inventory) || !reflect.DeepEqual(status.Targets, savedStatus.Targets) {
+				componentBackoff.Forget(req)
 			}
 			if status.ProcessingSince == nil {
 				status.ProcessingSince = &now
 			}
 			status.SetState(StateProcessing, ReadyConditionReasonProcessing, "Reconcilation of dependent resources triggered; waiting until all dependent resources are ready")
-			return ctrl.Result{RequeueAfter: r.backoff.Next(req, ReadyConditionReasonProcessing)}, nil
+			return ctrl.Result{RequeueAfter: componentBackoff.Next(req, ReadyConditionReasonProcessing)}, nil
 		}
 	} else {
+		// hold deletion outside of maintenance windows (if so configured)
+		if maintenanceWindowConfiguration, ok := assertMaintenanceWindowConfiguration(component); ok && maintenanceWindowConfiguration.GetMaintenanceWindowDeletionPolicy() == MaintenanceWindowPolicyHold {
+			active, next, err := checkMaintenanceWindows(maintenanceWindowConfiguration.GetMaintenanceWindows(), now.Time)
+			if err != nil {
+				return ctrl.Result{}, legacyerrors.Wrap(err, "error checking maintenance windows")
+			}
+			if !active {
+				log.V(1).Info("holding deletion until next maintenance window", "nextMaintenanceWindow", next)
+				status.SetState(StateDeletionPending, ReadyConditionReasonOutsideMaintenanceWindow, fmt.Sprintf("Deletion will happen in the next maintenance window (starting at %s)", next.UTC().Format(time.RFC3339)))
+				return ctrl.Result{RequeueAfter: next.Sub(now.Time)}, nil
+			}
+		}
+		// check again whether this replica is still responsible for the component (see above)
+		if !r.isResponsible(component) {
+			log.V(1).Info("moved to another shard; aborting reconciliation")
+			fenced = true
+			return ctrl.Result{}, nil
+		}
 		for hookOrder, hook := range r.preDeleteHooks {
 			if err := hook(hookCtx, r.hookClient, component); err != nil {
 				return ctrl.Result{}, legacyerrors.Wrapf(err, "error running pre-delete hook (%d)", hookOrder)
@@ -589,18 +927,18 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 			// TODO: have an additional StateDeletionBlocked?
 			// TODO: eliminate this msg logic
 			status.SetState(StateDeleting, ReadyConditionReasonDeletionBlocked, "Deletion blocked: "+msg)
-			return ctrl.Result{RequeueAfter: 1*time.Second + r.backoff.Next(req, ReadyConditionReasonDeletionBlocked)}, nil
+			return ctrl.Result{RequeueAfter: 1*time.Second + componentBackoff.Next(req, ReadyConditionReasonDeletionBlocked)}, nil
 		}
 		if len(slices.Remove(component.GetFinalizers(), *r.options.Finalizer)) > 0 {
 			// deletion is blocked because of foreign finalizers
 			log.V(1).Info("deleted blocked due to existence of foreign finalizers")
 			// TODO: have an additional StateDeletionBlocked?
 			status.SetState(StateDeleting, ReadyConditionReasonDeletionBlocked, "Deletion blocked due to existing foreign finalizers")
-			return ctrl.Result{RequeueAfter: 1*time.Second + r.backoff.Next(req, ReadyConditionReasonDeletionBlocked)}, nil
+			return ctrl.Result{RequeueAfter: 1*time.Second + componentBackoff.Next(req, ReadyConditionReasonDeletionBlocked)}, nil
 		}
 		// deletion case
 		log.V(2).Info("deleting dependent resources")
-		ok, err := target.Delete(ctx, component)
+		ok, err := target.Delete(ctx, component, componentDigest)
 		if err != nil {
 			log.V(1).Info("error while deleting dependent resources")
 			return ctrl.Result{}, legacyerrors.Wrap(err, "error deleting dependent resources")
@@ -626,11 +964,11 @@ func (r *Reconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (result
 		} else {
 			// deletion triggered for dependent resources, but some are not yet gone
 			log.V(1).Info("not all dependent resources are successfully deleted")
-			if !reflect.DeepEqual(status.Inventory, savedStatus.Inventory) {
-				r.backoff.Forget(req)
+			if !reflect.DeepEqual(status.Inventory, savedStatus.Inventory) || !reflect.DeepEqual(status.Targets, savedStatus.Targets) {
+				componentBackoff.Forget(req)
 			}
 			status.SetState(StateDeleting, ReadyConditionReasonDeletionProcessing, "Deletion of dependent resources triggered; waiting until dependent resources are deleted")
-			return ctrl.Result{RequeueAfter: r.backoff.Next(req, ReadyConditionReasonDeletionProcessing)}, nil
+			return ctrl.Result{RequeueAfter: componentBackoff.Next(req, ReadyConditionReasonDeletionProcessing)}, nil
 		}
 	}
 }
@@ -726,9 +1064,62 @@ func (r *Reconciler[T]) SetupWithManagerAndBuilder(mgr ctrl.Manager, blder *ctrl
 		panic("usage error: setup must not be called more than once")
 	}
 
+	if err := r.setupReferenceNamespaces(); err != nil {
+		return err
+	}
+	config, err := r.setupClients(mgr)
+	if err != nil {
+		return err
+	}
+	component := newComponent[T]()
+
+	componentPredicate := predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
+	if r.options.Sharding != nil {
+		if err := r.setupSharding(mgr, config); err != nil {
+			return legacyerrors.Wrap(err, "error setting up sharding")
+		}
+		// note: label changes must be considered, because they might move the component into another shard
+		componentPredicate = predicate.And(
+			predicate.NewPredicateFuncs(r.sharder.isResponsible),
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}, predicate.LabelChangedPredicate{}),
+		)
+	}
+
+	if err := blder.
+		For(component, builder.WithPredicates(componentPredicate)).
+		WatchesRawSource(source.Channel(
+			r.triggerCh,
+			handler.TypedFuncs[apitypes.NamespacedName, reconcile.Request]{GenericFunc: func(ctx context.Context, e event.TypedGenericEvent[apitypes.NamespacedName], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				q.Add(reconcile.Request{NamespacedName: e.Object})
+			}},
+			source.WithBufferSize[apitypes.NamespacedName, reconcile.Request](triggerBufferSize))).
+		Named(r.controllerName).
+		Complete(r); err != nil {
+		return legacyerrors.Wrap(err, "error creating controller")
+	}
+
+	r.setupComplete = true
+	return nil
+}
+
+// Compile the patterns passed as ReferenceNamespaces option.
+func (r *Reconciler[T]) setupReferenceNamespaces() error {
+	r.referenceNamespaces = nil
+	for _, pattern := range r.options.ReferenceNamespaces {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return legacyerrors.Wrapf(err, "invalid reference namespace pattern: %s", pattern)
+		}
+		r.referenceNamespaces = append(r.referenceNamespaces, g)
+	}
+	return nil
+}
+
+// Setup the clients used by the reconciler (or by a validator); returns the (instrumented) rest config underlying these clients.
+func (r *Reconciler[T]) setupClients(mgr ctrl.Manager) (*rest.Config, error) {
 	kubeSystemNamespace := &corev1.Namespace{}
 	if err := mgr.GetAPIReader().Get(context.Background(), apitypes.NamespacedName{Name: "kube-system"}, kubeSystemNamespace); err != nil {
-		return legacyerrors.Wrap(err, "error retrieving uid of kube-system namespace")
+		return nil, legacyerrors.Wrap(err, "error retrieving uid of kube-system namespace")
 	}
 	r.id = string(kubeSystemNamespace.UID)
 
@@ -744,7 +1135,7 @@ func (r *Reconciler[T]) SetupWithManagerAndBuilder(mgr ctrl.Manager, blder *ctrl
 	if r.options.NewClient != nil {
 		clnt, err := r.options.NewClient(r.client)
 		if err != nil {
-			return legacyerrors.Wrap(err, "error calling custom client constructor")
+			return nil, legacyerrors.Wrap(err, "error calling custom client constructor")
 		}
 		r.client = clnt
 	}
@@ -752,14 +1143,13 @@ func (r *Reconciler[T]) SetupWithManagerAndBuilder(mgr ctrl.Manager, blder *ctrl
 
 	discoveryClient, err := discovery.NewDiscoveryClientForConfigAndClient(config, mgr.GetHTTPClient())
 	if err != nil {
-		return legacyerrors.Wrap(err, "error creating discovery client")
+		return nil, legacyerrors.Wrap(err, "error creating discovery client")
 	}
 	r.hookClient = cluster.NewClient(mgr.GetClient(), discoveryClient, mgr.GetEventRecorderFor(r.name), config, mgr.GetHTTPClient())
 
-	component := newComponent[T]()
-	r.groupVersionKind, err = apiutil.GVKForObject(component, r.client.Scheme())
+	r.groupVersionKind, err = apiutil.GVKForObject(newComponent[T](), r.client.Scheme())
 	if err != nil {
-		return legacyerrors.Wrap(err, "error getting type metadata for component")
+		return nil, legacyerrors.Wrap(err, "error getting type metadata for component")
 	}
 	// TODO: should this be more fully qualified, or configurable?
 	// for now we reproduce the controller-runtime default (the lowercase kind of the reconciled type)
@@ -772,37 +1162,98 @@ func (r *Reconciler[T]) SetupWithManagerAndBuilder(mgr ctrl.Manager, blder *ctrl
 	if r.options.SchemeBuilder != nil {
 		schemeBuilders = append(schemeBuilders, r.options.SchemeBuilder)
 	}
-	r.clients, err = clientfactory.NewClientFactory(r.name, r.controllerName, config, schemeBuilders)
+	r.clients, err = clientfactory.NewClientFactory(r.name, r.controllerName, config, schemeBuilders, clientfactory.ClientFactoryOptions{
+		TTL:                        *r.options.ClientTTL,
+		AllowExecCredentialPlugins: *r.options.EnableExecCredentialPlugins,
+	})
 	if err != nil {
-		return legacyerrors.Wrap(err, "error creating client factory")
+		return nil, legacyerrors.Wrap(err, "error creating client factory")
 	}
 
-	if err := blder.
-		For(component, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
-		WatchesRawSource(source.Channel(
-			r.triggerCh,
-			handler.TypedFuncs[apitypes.NamespacedName, reconcile.Request]{GenericFunc: func(ctx context.Context, e event.TypedGenericEvent[apitypes.NamespacedName], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-				q.Add(reconcile.Request{NamespacedName: e.Object})
-			}},
-			source.WithBufferSize[apitypes.NamespacedName, reconcile.Request](triggerBufferSize))).
-		Named(r.controllerName).
-		Complete(r); err != nil {
-		return legacyerrors.Wrap(err, "error creating controller")
-	}
-
-	r.setupComplete = true
-	return nil
+	return config, nil
 }
 
 // Register the reconciler with a given controller-runtime Manager.
+// Note: if sharding is enabled, the controller will run in all replicas (regardless of leader election);
+// callers using SetupWithManagerAndBuilder() have to take care of that on their own (by setting NeedLeaderElection to false
+// in the controller options).
 func (r *Reconciler[T]) SetupWithManager(mgr ctrl.Manager) error {
+	var needLeaderElection *bool
+	if r.options.Sharding != nil {
+		needLeaderElection = new(false)
+	}
 	return r.SetupWithManagerAndBuilder(
 		mgr,
 		ctrl.NewControllerManagedBy(mgr).
-			WithOptions(controller.Options{MaxConcurrentReconciles: 5}),
+			WithOptions(controller.Options{MaxConcurrentReconciles: 5, RateLimiter: r.options.QueueRateLimiter, NeedLeaderElection: needLeaderElection}),
 	)
 }
 
+func (r *Reconciler[T]) setupSharding(mgr ctrl.Manager, config *rest.Config) error {
+	sharding := r.options.Sharding
+	switch sharding.Mode {
+	case ShardingModeLabel:
+		r.sharder = &labelSharder{
+			labelKey: r.name + "/" + types.LabelKeySuffixShard,
+			shard:    sharding.Shard,
+		}
+	case ShardingModeHash:
+		identity := sharding.Identity
+		if identity == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return legacyerrors.Wrap(err, "error determining shard identity")
+			}
+			identity = hostname
+		}
+		namespace := sharding.LeaseNamespace
+		if namespace == "" {
+			data, err := os.ReadFile(serviceAccountNamespaceFile)
+			if err != nil {
+				return legacyerrors.Wrap(err, "error determining shard lease namespace")
+			}
+			namespace = strings.TrimSpace(string(data))
+		}
+		leasesClient, err := coordinationv1client.NewForConfigAndClient(config, mgr.GetHTTPClient())
+		if err != nil {
+			return legacyerrors.Wrap(err, "error creating lease client")
+		}
+		hashSharder := newHashSharder(leasesClient, namespace, r.name+"/"+types.LabelKeySuffixShardGroup, r.controllerName, identity, sharding.LeaseDuration, sharding.RenewInterval)
+		// if the members change, trigger all components which this replica is responsible for (since some of them might be new to this replica);
+		// note: components which moved to other replicas are dropped (when they are reconciled by this replica the next time)
+		hashSharder.onChange = func(ctx context.Context) error {
+			componentList := &metav1.PartialObjectMetadataList{}
+			componentList.SetGroupVersionKind(r.groupVersionKind.GroupVersion().WithKind(r.groupVersionKind.Kind + "List"))
+			if err := r.client.List(ctx, componentList); err != nil {
+				return legacyerrors.Wrap(err, "error listing components")
+			}
+			for _, component := range componentList.Items {
+				if !hashSharder.isResponsible(&component) {
+					continue
+				}
+				select {
+				case r.triggerCh <- event.TypedGenericEvent[apitypes.NamespacedName]{Object: apitypes.NamespacedName{Namespace: component.Namespace, Name: component.Name}}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+			return nil
+		}
+		if err := mgr.Add(hashSharder); err != nil {
+			return legacyerrors.Wrap(err, "error registering shard membership runnable")
+		}
+		r.sharder = hashSharder
+	default:
+		return fmt.Errorf("invalid sharding mode: %s", sharding.Mode)
+	}
+	return nil
+}
+
+// Check whether this replica is responsible for the given component (which is always the case if sharding is disabled).
+func (r *Reconciler[T]) isResponsible(component T) bool {
+	return r.sharder == nil || r.sharder.isResponsible(component)
+}
+
 func (r *Reconciler[T]) getLocalClientForComponent(component T) (cluster.Client, error) {
 	impersonationConfiguration, haveImpersonationConfiguration := assertImpersonationConfiguration(component)
 
@@ -868,13 +1319,155 @@ func (r *Reconciler[T]) getClientForComponent(component T) (cluster.Client, erro
 	if len(kubeConfig) == 0 && impersonationUser == "" && len(impersonationGroups) == 0 && r.options.DefaultServiceAccount != nil && *r.options.DefaultServiceAccount != "" {
 		impersonationUser = fmt.Sprintf("system:serviceaccount:%s:%s", component.GetNamespace(), *r.options.DefaultServiceAccount)
 	}
-	clnt, err := r.clients.Get(kubeConfig, impersonationUser, impersonationGroups)
+	// note: passing the component as owner ensures that the previous client is dropped if the kubeconfig changes (e.g. because credentials were rotated)
+	clnt, err := r.clients.GetFor(component.GetNamespace()+"/"+component.GetName(), kubeConfig, impersonationUser, impersonationGroups)
 	if err != nil {
 		return nil, legacyerrors.Wrap(err, "error getting target client")
 	}
 	return clnt, nil
 }
 
+func (r *Reconciler[T]) newContextForComponent(ctx context.Context, component T, componentDigest string) context.Context {
+	logger := log.FromContext(ctx).WithValues(
+		"componentKind", r.groupVersionKind.Kind,
+		"componentNamespace", component.GetNamespace(),
+		"componentName", component.GetName(),
+		"componentDigest", componentDigest,
+		"componentRevision", component.GetStatus().Revision,
+	)
+	return NewContext(ctx).
+		WithLogger(logger).
+		WithEventRecorder(newEventRecorder(&r.eventRecorder, component))
+}
+
+// Return the backoff for the given component; if the component overrides the maximum backoff delay (clamped to a sane range),
+// the returned backoff applies it on top of the configured rate limiter.
+func (r *Reconciler[T]) getBackoffForComponent(component T) *backoff.MaxDelayBackoff {
+	maxBackoff := time.Duration(0)
+	if backoffConfiguration, ok := assertBackoffConfiguration(component); ok {
+		if maxBackoff = backoffConfiguration.GetMaxBackoff(); maxBackoff > 0 {
+			maxBackoff = min(max(maxBackoff, minMaxBackoff), maxMaxBackoff)
+		}
+	}
+	return r.backoff.WithMaxDelay(maxBackoff)
+}
+
+// Return the target(s) the dependent objects of the given component are deployed to. If validateOnly is true (that is, if called
+// from the admission webhook), the kubeconfigs of the targets are not remembered, and no removed targets are returned.
+func (r *Reconciler[T]) getTargetForComponent(ctx context.Context, component T, localClient cluster.Client, targetClient cluster.Client, validateOnly bool) (componentTarget[T], error) {
+	targetOptions := r.getOptionsForComponent(component)
+	if multiTargetConfiguration, ok := assertMultiTargetConfiguration(component); ok {
+		targets, err := multiTargetConfiguration.GetTargets()
+		if err != nil {
+			return nil, legacyerrors.Wrap(err, "error getting targets")
+		}
+		if len(targets) > 0 {
+			if outputConfiguration, ok := assertOutputConfiguration(component); ok && outputConfiguration.GetConnectionSecretName() != "" {
+				return nil, fmt.Errorf("connection secret is not supported for components with multiple targets")
+			}
+			componentKey := apitypes.NamespacedName{Namespace: component.GetNamespace(), Name: component.GetName()}
+			status := component.GetStatus()
+			// note: the admission webhook must not remember kubeconfigs, since the component might never be admitted
+			kubeConfigs := make(map[string][]byte)
+			if !validateOnly {
+				r.targetKubeConfigMutex.Lock()
+				defer r.targetKubeConfigMutex.Unlock()
+				if r.targetKubeConfigs[componentKey] == nil {
+					r.targetKubeConfigs[componentKey] = kubeConfigs
+				}
+				kubeConfigs = r.targetKubeConfigs[componentKey]
+			}
+			var reconcileTargets []*reconcileTarget[T]
+			for i, target := range targets {
+				if target.Name == "" {
+					return nil, fmt.Errorf("target (%d) has an empty name", i)
+				}
+				if slices.Any(targets[:i], func(t Target) bool { return t.Name == target.Name }) {
+					return nil, fmt.Errorf("duplicate target %s", target.Name)
+				}
+				if len(target.KubeConfig) == 0 {
+					return nil, fmt.Errorf("target %s has an empty kubeconfig", target.Name)
+				}
+				clnt, err := r.clients.GetFor(component.GetNamespace()+"/"+component.GetName()+"/"+target.Name, target.KubeConfig, "", nil)
+				if err != nil {
+					return nil, legacyerrors.Wrapf(err, "error getting client for target %s", target.Name)
+				}
+				kubeConfigs[target.Name] = target.KubeConfig
+				reconcileTarget := newReconcileTarget[T](r.name, r.id, localClient, clnt, r.resourceGenerator, targetOptions)
+				reconcileTarget.targetName = target.Name
+				reconcileTarget.parameterOverrides = target.ParameterOverrides
+				if target.KubeConfigSecretRef != nil {
+					reconcileTarget.kubeConfigSecretRef = &SecretKeyReference{
+						Namespace: referenceNamespace(target.KubeConfigSecretRef.Namespace, component.GetNamespace()),
+						Name:      target.KubeConfigSecretRef.Name,
+						Key:       target.KubeConfigSecretRef.Key,
+					}
+				}
+				reconcileTargets = append(reconcileTargets, reconcileTarget)
+			}
+			// targets which were removed (including the single target, if the component was switched to multi-target mode),
+			// but still have dependent objects, are passed as removed targets, such that their dependent objects get deleted;
+			// this is only possible if the kubeconfig of the removed target is known, that is, if it is still remembered by the reconciler,
+			// or if it can be loaded from the secret recorded in the status of the removed target
+			if validateOnly {
+				return newMultiReconcileTarget(reconcileTargets, nil, multiTargetConfiguration.GetRolloutStrategy()), nil
+			}
+			var removedTargets []*reconcileTarget[T]
+			if len(status.Inventory) > 0 {
+				removedTargets = append(removedTargets, newReconcileTarget[T](r.name, r.id, localClient, targetClient, r.resourceGenerator, targetOptions))
+			}
+			for name := range kubeConfigs {
+				if !slices.Any(targets, func(t Target) bool { return t.Name == name }) &&
+					!slices.Any(status.Targets, func(s TargetStatus) bool { return s.Name == name && len(s.Inventory) > 0 }) {
+					delete(kubeConfigs, name)
+				}
+			}
+			for _, targetStatus := range status.Targets {
+				if len(targetStatus.Inventory) == 0 || slices.Any(targets, func(t Target) bool { return t.Name == targetStatus.Name }) {
+					continue
+				}
+				kubeConfig, ok := kubeConfigs[targetStatus.Name]
+				if !ok && targetStatus.KubeConfigSecretRef != nil {
+					if kubeConfig, err = r.loadKubeConfigOfRemovedTarget(ctx, component, targetStatus.KubeConfigSecretRef); err != nil {
+						return nil, legacyerrors.Wrapf(err, "error loading kubeconfig of removed target %s", targetStatus.Name)
+					}
+				}
+				if len(kubeConfig) == 0 {
+					continue
+				}
+				clnt, err := r.clients.GetFor(component.GetNamespace()+"/"+component.GetName()+"/"+targetStatus.Name, kubeConfig, "", nil)
+				if err != nil {
+					return nil, legacyerrors.Wrapf(err, "error getting client for removed target %s", targetStatus.Name)
+				}
+				removedTarget := newReconcileTarget[T](r.name, r.id, localClient, clnt, r.resourceGenerator, targetOptions)
+				removedTarget.targetName = targetStatus.Name
+				removedTargets = append(removedTargets, removedTarget)
+			}
+			return newMultiReconcileTarget(reconcileTargets, removedTargets, multiTargetConfiguration.GetRolloutStrategy()), nil
+		}
+	}
+	return newReconcileTarget[T](r.name, r.id, localClient, targetClient, r.resourceGenerator, targetOptions), nil
+}
+
+// Load the kubeconfig of a removed target from the secret recorded in the target's status; returns nil if the secret does not exist,
+// or does not contain the kubeconfig (anymore).
+func (r *Reconciler[T]) loadKubeConfigOfRemovedTarget(ctx context.Context, component T, secretRef *SecretKeyReference) ([]byte, error) {
+	field, _ := reflect.TypeFor[KubeConfigSpec]().FieldByName("SecretRef")
+	fallbackKeys := strings.Split(field.Tag.Get(tagFallbackKeys), ",")
+	ref := &SecretKeyReference{Namespace: secretRef.Namespace, Name: secretRef.Name, Key: secretRef.Key}
+	referenceClient := newReferenceClient(r.client, component.GetNamespace(), r.referenceNamespaces, r.name+"/"+types.AnnotationKeySuffixReferenceGrant)
+	if err := ref.load(ctx, referenceClient, referenceNamespace(ref.Namespace, component.GetNamespace()), true, fallbackKeys...); err != nil {
+		if errors.As(err, &types.RetriableError{}) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !ref.loaded {
+		return nil, nil
+	}
+	return ref.value, nil
+}
+
 func (r *Reconciler[T]) getOptionsForComponent(component T) reconciler.ReconcilerOptions {
 	options := reconciler.ReconcilerOptions{
 		FieldOwner:              r.options.FieldOwner,
@@ -885,6 +1478,7 @@ func (r *Reconciler[T]) getOptionsForComponent(component T) reconciler.Reconcile
 		MissingNamespacesPolicy: r.options.MissingNamespacesPolicy,
 		ReapplyInterval:         r.options.ReapplyInterval,
 		StatusAnalyzer:          r.statusAnalyzer,
+		TracerProvider:          r.options.TracerProvider,
 		Metrics: reconciler.ReconcilerMetrics{
 			ReadCounter:   metrics.Operations.WithLabelValues(r.controllerName, "read"),
 			CreateCounter: metrics.Operations.WithLabelValues(r.controllerName, "create"),
@@ -0,0 +1,134 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = ginkgo.Describe("testing: reconciler.go", func() {
+	var component *testComponent
+
+	ginkgo.BeforeEach(func() {
+		component = newTestComponent("default", "test")
+	})
+
+	nextBackoffs := func(r *Reconciler[*testComponent], n int) []time.Duration {
+		var delays []time.Duration
+		for range n {
+			delays = append(delays, r.getBackoffForComponent(component).Next("item", "activity"))
+		}
+		return delays
+	}
+
+	ginkgo.It("should back off to 10 seconds by default", func() {
+		r := NewReconciler[*testComponent]("test", nil, ReconcilerOptions{})
+		Expect(nextBackoffs(r, 51)[50]).To(Equal(10 * time.Second))
+	})
+
+	ginkgo.It("should let components override the maximum delay of the default rate limiter", func() {
+		r := NewReconciler[*testComponent]("test", nil, ReconcilerOptions{})
+		component.Spec.MaxBackoff = &metav1.Duration{Duration: 30 * time.Second}
+		Expect(nextBackoffs(r, 51)[50]).To(Equal(30 * time.Second))
+	})
+
+	ginkgo.It("should clamp the maximum delay overridden by components", func() {
+		r := NewReconciler[*testComponent]("test", nil, ReconcilerOptions{})
+		component.Spec.MaxBackoff = &metav1.Duration{Duration: 24 * time.Hour}
+		Expect(nextBackoffs(r, 51)[50]).To(Equal(1 * time.Hour))
+		r.getBackoffForComponent(component).Forget("item")
+		component.Spec.MaxBackoff = &metav1.Duration{Duration: time.Millisecond}
+		Expect(nextBackoffs(r, 51)).To(HaveEach(BeNumerically("<=", 1*time.Second)))
+		Expect(nextBackoffs(r, 1)[0]).To(Equal(1 * time.Second))
+	})
+
+	ginkgo.It("should use the configured rate limiter, and let components cap its delays", func() {
+		r := NewReconciler[*testComponent]("test", nil, ReconcilerOptions{
+			BackoffRateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 1*time.Hour),
+		})
+		Expect(nextBackoffs(r, 4)).To(Equal([]time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}))
+		component.Spec.MaxBackoff = &metav1.Duration{Duration: 10 * time.Second}
+		Expect(nextBackoffs(r, 2)).To(Equal([]time.Duration{10 * time.Second, 10 * time.Second}))
+	})
+
+	ginkgo.It("should not allow exec credential plugins by default", func() {
+		r := NewReconciler[*testComponent]("test", nil, ReconcilerOptions{})
+		Expect(*r.options.EnableExecCredentialPlugins).To(BeFalse())
+	})
+
+	ginkgo.Describe("circuit breaker", func() {
+		var clnt client.Client
+		var r *Reconciler[*testComponent]
+		var hookErr error
+
+		ginkgo.BeforeEach(func() {
+			clnt = newTestClient(component)
+			r = newTestReconciler(clnt, ReconcilerOptions{CircuitBreaker: &CircuitBreakerOptions{Threshold: 3}}).
+				WithPostReadHook(func(ctx context.Context, clnt client.Client, component *testComponent) error {
+					return hookErr
+				})
+			r.setupComplete = true
+		})
+
+		reconcile := func() *testComponent {
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(component)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clnt.Get(context.Background(), client.ObjectKeyFromObject(component), component)).To(Succeed())
+			return component
+		}
+
+		ginkgo.It("should never stall components which keep returning a retriable error", func() {
+			hookErr = types.NewRetriableError(fmt.Errorf("waiting for dependency"), nil)
+			for range 10 {
+				Expect(reconcile().Status.State).To(Equal(StatePending))
+			}
+			Expect(component.Status.ConsecutiveFailures).To(BeZero())
+		})
+
+		ginkgo.It("should stall components which keep failing with the same error", func() {
+			hookErr = types.NewReconcileError(fmt.Errorf("something failed"), types.ErrorStateError, "", "").WithRetryAfter(time.Second)
+			reconcile()
+			Expect(reconcile().Status.State).To(Equal(StateError))
+			Expect(reconcile().Status.State).To(Equal(StateError))
+			Expect(reconcile().Status.State).To(Equal(StateStalled))
+		})
+
+		ginkgo.It("should resume stalled components on retry request without considering the component as changed", func() {
+			hookErr = types.NewReconcileError(fmt.Errorf("something failed"), types.ErrorStateError, "", "").WithRetryAfter(time.Second)
+			reconcile()
+			component.Status.Revision = 1
+			Expect(clnt.Status().Update(context.Background(), component)).To(Succeed())
+			for range 3 {
+				reconcile()
+			}
+			Expect(component.Status.State).To(Equal(StateStalled))
+			processingDigest := component.Status.ProcessingDigest
+			Expect(processingDigest).NotTo(BeEmpty())
+
+			component.SetAnnotations(map[string]string{"test/" + types.AnnotationKeySuffixRetry: "1"})
+			Expect(clnt.Update(context.Background(), component)).To(Succeed())
+			hookErr = types.NewReconcileError(fmt.Errorf("something else failed"), types.ErrorStateError, "", "").WithRetryAfter(time.Second)
+			reconcile()
+			Expect(component.Status.State).To(Equal(StateError))
+			Expect(component.Status.StalledSince).To(BeNil())
+			Expect(component.Status.ConsecutiveFailures).To(Equal(1))
+			Expect(component.Status.ProcessingDigest).To(Equal(processingDigest))
+			Expect(component.Status.Revision).To(Equal(int64(1)))
+		})
+	})
+})
@@ -8,11 +8,14 @@ package component
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"strings"
 	"time"
 
+	"github.com/gobwas/glob"
 	legacyerrors "github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
@@ -33,9 +36,13 @@ import (
 const (
 	tagNotFoundPolicy = "notFoundPolicy"
 	tagFallbackKeys   = "fallbackKeys"
+	tagCluster        = "cluster"
 
 	notFoundPolicyIgnoreOnDeletion = "ignoreOnDeletion"
 
+	clusterLocal  = "local"
+	clusterTarget = "target"
+
 	retryAfter = 10 * time.Second
 )
 
@@ -43,6 +50,9 @@ const (
 
 // ConfigMapReference defines a loadable reference to a configmap.
 type ConfigMapReference struct {
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace,omitempty"`
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	Name   string            `json:"name"`
@@ -54,10 +64,10 @@ func (r *ConfigMapReference) load(ctx context.Context, clnt client.Client, names
 	// TODO: shouldn't we panic if already loaded?
 	configMap := &corev1.ConfigMap{}
 	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: r.Name}, configMap); err != nil {
+		if ignoreNotFound && isNotFoundOrNotPermitted(err) {
+			return nil
+		}
 		if apierrors.IsNotFound(err) {
-			if ignoreNotFound {
-				return nil
-			}
 			return types.NewRetriableError(legacyerrors.Wrapf(err, "error loading configmap %s/%s", namespace, r.Name), new(retryAfter))
 		} else {
 			return legacyerrors.Wrapf(err, "error loading configmap %s/%s", namespace, r.Name)
@@ -89,6 +99,9 @@ func (r *ConfigMapReference) Data() map[string]string {
 
 // ConfigMapKeyReference defines a loadable reference to a configmap key.
 type ConfigMapKeyReference struct {
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace,omitempty"`
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
@@ -103,10 +116,10 @@ func (r *ConfigMapKeyReference) load(ctx context.Context, clnt client.Client, na
 	// TODO: shouldn't we panic if already loaded?
 	configMap := &corev1.ConfigMap{}
 	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: r.Name}, configMap); err != nil {
+		if ignoreNotFound && isNotFoundOrNotPermitted(err) {
+			return nil
+		}
 		if apierrors.IsNotFound(err) {
-			if ignoreNotFound {
-				return nil
-			}
 			return types.NewRetriableError(legacyerrors.Wrapf(err, "error loading configmap %s/%s", namespace, r.Name), new(retryAfter))
 		} else {
 			return legacyerrors.Wrapf(err, "error loading configmap %s/%s", namespace, r.Name)
@@ -153,6 +166,9 @@ func (r *ConfigMapKeyReference) Value() string {
 
 // SecretReference defines a loadable reference to a secret.
 type SecretReference struct {
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace,omitempty"`
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	Name   string            `json:"name"`
@@ -164,10 +180,10 @@ func (r *SecretReference) load(ctx context.Context, clnt client.Client, namespac
 	// TODO: shouldn't we panic if already loaded?
 	secret := &corev1.Secret{}
 	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: r.Name}, secret); err != nil {
+		if ignoreNotFound && isNotFoundOrNotPermitted(err) {
+			return nil
+		}
 		if apierrors.IsNotFound(err) {
-			if ignoreNotFound {
-				return nil
-			}
 			return types.NewRetriableError(legacyerrors.Wrapf(err, "error loading secret %s/%s", namespace, r.Name), new(retryAfter))
 		} else {
 			return legacyerrors.Wrapf(err, "error loading secret %s/%s", namespace, r.Name)
@@ -199,6 +215,9 @@ func (r *SecretReference) Data() map[string][]byte {
 
 // SecretKeyReference defines a loadable reference to a secret key.
 type SecretKeyReference struct {
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace,omitempty"`
 	// +required
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
@@ -213,10 +232,10 @@ func (r *SecretKeyReference) load(ctx context.Context, clnt client.Client, names
 	// TODO: shouldn't we panic if already loaded?
 	secret := &corev1.Secret{}
 	if err := clnt.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: r.Name}, secret); err != nil {
+		if ignoreNotFound && isNotFoundOrNotPermitted(err) {
+			return nil
+		}
 		if apierrors.IsNotFound(err) {
-			if ignoreNotFound {
-				return nil
-			}
 			return types.NewRetriableError(legacyerrors.Wrapf(err, "error loading secret %s/%s", namespace, r.Name), new(retryAfter))
 		} else {
 			return legacyerrors.Wrapf(err, "error loading secret %s/%s", namespace, r.Name)
@@ -274,73 +293,181 @@ type Reference[T Component] interface {
 	Digest() string
 }
 
-func resolveReferences[T Component](ctx context.Context, clnt client.Client, hookClient client.Client, component T) (string, error) {
+// Client wrapper used to load configmap or secret references. Objects in the component's namespace can be read without
+// restrictions. Objects in other namespaces can only be read if their namespace matches one of the allowed namespace patterns,
+// or if the object itself grants access to the component's namespace by the reference-grant annotation (a comma-separated list
+// of namespaces, or "*").
+type referenceClient struct {
+	client.Client
+	namespace          string
+	allowedNamespaces  []glob.Glob
+	grantAnnotationKey string
+}
+
+func newReferenceClient(clnt client.Client, namespace string, allowedNamespaces []glob.Glob, grantAnnotationKey string) *referenceClient {
+	return &referenceClient{
+		Client:             clnt,
+		namespace:          namespace,
+		allowedNamespaces:  allowedNamespaces,
+		grantAnnotationKey: grantAnnotationKey,
+	}
+}
+
+// Get the object; objects in namespaces other than the component's namespace (which are not allowed by the namespace patterns) are read
+// to check their grant annotation, but if this fails (for example, because the object does not exist), the same error is returned as
+// if access was not granted, such that the existence of objects in namespaces the component may not access is not revealed.
+func (c *referenceClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if key.Namespace == "" || key.Namespace == c.namespace {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	for _, allowedNamespace := range c.allowedNamespaces {
+		if allowedNamespace.Match(key.Namespace) {
+			return c.Client.Get(ctx, key, obj, opts...)
+		}
+	}
+	if err := c.Client.Get(ctx, key, obj, opts...); err == nil {
+		if grant, ok := obj.GetAnnotations()[c.grantAnnotationKey]; ok {
+			for _, namespace := range strings.Split(grant, ",") {
+				if namespace = strings.TrimSpace(namespace); namespace == "*" || namespace == c.namespace {
+					return nil
+				}
+			}
+		}
+	}
+	// note: the error is retriable because the referenced object might grant access later
+	return types.NewRetriableError(&referenceNotPermittedError{key: key, namespace: c.namespace}, new(retryAfter))
+}
+
+// Error returned by referenceClient if the component is not permitted to read the referenced object
+// (regardless of whether the object exists).
+type referenceNotPermittedError struct {
+	key       client.ObjectKey
+	namespace string
+}
+
+func (e *referenceNotPermittedError) Error() string {
+	return fmt.Sprintf("reference to %s/%s is not permitted from namespace %s", e.key.Namespace, e.key.Name, e.namespace)
+}
+
+// Check if the given error indicates that the referenced object does not exist, or that it must not be read;
+// since referenceClient does not reveal whether objects exist which the component may not access, both cases are treated alike
+// if not-found errors are to be ignored.
+func isNotFoundOrNotPermitted(err error) bool {
+	return apierrors.IsNotFound(err) || errors.As(err, new(*referenceNotPermittedError))
+}
+
+// Return the namespace of a configmap or secret reference (defaulting to the component's namespace).
+func referenceNamespace(namespace string, componentNamespace string) string {
+	if namespace == "" {
+		return componentNamespace
+	}
+	return namespace
+}
+
+// Resolve all references occurring in the component's spec, and return a digest of the component, including the digests of the loaded references.
+// References are resolved in two passes: first all references which are to be loaded from the local cluster (this is the default); then all
+// references which are tagged with cluster:"target", using the client returned by getTargetClient (which is called at most once, and only
+// if there are target references); this order ensures that references needed to build the target client (such as kubeconfig references)
+// are loaded before the target client is requested.
+// The digest does not include the <reconciler-name>/approved-plan annotation (since the digest of the approved plan is derived from the component digest),
+// nor the <reconciler-name>/retry annotation (since requesting a retry of a stalled component is not a change of the component),
+// nor the <reconciler-name>/skip-delete-hooks annotation (since it only affects the deletion of the component).
+func resolveReferences[T Component](ctx context.Context, clnt client.Client, hookClient client.Client, getTargetClient func() (client.Client, error), component T, allowedNamespaces []glob.Glob, reconcilerName string) (string, error) {
 	digestData := make(map[string]any)
 	spec := getSpec(component)
 	digestData["generation"] = component.GetGeneration()
-	digestData["annotations"] = component.GetAnnotations()
+	annotations := maps.Clone(component.GetAnnotations())
+	delete(annotations, reconcilerName+"/"+types.AnnotationKeySuffixApprovedPlan)
+	delete(annotations, reconcilerName+"/"+types.AnnotationKeySuffixRetry)
+	delete(annotations, reconcilerName+"/"+types.AnnotationKeySuffixSkipDeleteHooks)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	digestData["annotations"] = annotations
 	// TODO: including spec into the digest is actually not required (since generation is included)
 	digestData["spec"] = spec
-	if err := walk.Walk(spec, func(x any, path []string, tag reflect.StructTag) error {
-		// note: this Must() is ok because marshalling []string should always work
-		rawPath := util.Must(json.Marshal(path))
-		switch r := x.(type) {
-		case *ConfigMapReference:
-			if r == nil {
-				return nil
-			}
-			ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
-			if err := r.load(ctx, clnt, component.GetNamespace(), ignoreNotFound); err != nil {
-				return err
-			}
-			digestData["refs:"+string(rawPath)] = r.digest()
-		case *ConfigMapKeyReference:
-			if r == nil {
-				return nil
-			}
-			ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
-			var fallbackKeys []string
-			if s := tag.Get(tagFallbackKeys); s != "" {
-				fallbackKeys = strings.Split(s, ",")
-			}
-			if err := r.load(ctx, clnt, component.GetNamespace(), ignoreNotFound, fallbackKeys...); err != nil {
-				return err
-			}
-			digestData["refs:"+string(rawPath)] = r.digest()
-		case *SecretReference:
-			if r == nil {
+	var targetClient client.Client
+	for _, cluster := range []string{clusterLocal, clusterTarget} {
+		if err := walk.Walk(spec, func(x any, path []string, tag reflect.StructTag) error {
+			switch x.(type) {
+			case *ConfigMapReference, *ConfigMapKeyReference, *SecretReference, *SecretKeyReference, Reference[T]:
+			default:
 				return nil
 			}
-			ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
-			if err := r.load(ctx, clnt, component.GetNamespace(), ignoreNotFound); err != nil {
-				return err
-			}
-			digestData["refs:"+string(rawPath)] = r.digest()
-		case *SecretKeyReference:
-			if r == nil {
+			if v := reflect.ValueOf(x); x == nil || v.Kind() == reflect.Pointer && v.IsNil() {
 				return nil
 			}
-			ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
-			var fallbackKeys []string
-			if s := tag.Get(tagFallbackKeys); s != "" {
-				fallbackKeys = strings.Split(s, ",")
+			switch c := tag.Get(tagCluster); c {
+			case "", clusterLocal:
+				if cluster != clusterLocal {
+					return nil
+				}
+			case clusterTarget:
+				if cluster != clusterTarget {
+					return nil
+				}
+			default:
+				return fmt.Errorf("invalid value for tag %s: %s", tagCluster, c)
 			}
-			if err := r.load(ctx, clnt, component.GetNamespace(), ignoreNotFound, fallbackKeys...); err != nil {
-				return err
+			referenceClient := clnt
+			referenceHookClient := hookClient
+			if cluster == clusterTarget {
+				if targetClient == nil {
+					var err error
+					if targetClient, err = getTargetClient(); err != nil {
+						return legacyerrors.Wrap(err, "error getting target client for reference")
+					}
+				}
+				referenceClient = targetClient
+				referenceHookClient = targetClient
 			}
-			digestData["refs:"+string(rawPath)] = r.digest()
-		case Reference[T]:
-			if v := reflect.ValueOf(r); r == nil || v.Kind() == reflect.Pointer && v.IsNil() {
-				return nil
-			}
-			if err := r.Load(ctx, hookClient, component); err != nil {
-				return err
+			referenceClient = newReferenceClient(referenceClient, component.GetNamespace(), allowedNamespaces, reconcilerName+"/"+types.AnnotationKeySuffixReferenceGrant)
+			// note: this Must() is ok because marshalling []string should always work
+			rawPath := util.Must(json.Marshal(path))
+			switch r := x.(type) {
+			case *ConfigMapReference:
+				ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
+				if err := r.load(ctx, referenceClient, referenceNamespace(r.Namespace, component.GetNamespace()), ignoreNotFound); err != nil {
+					return err
+				}
+				digestData["refs:"+string(rawPath)] = r.digest()
+			case *ConfigMapKeyReference:
+				ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
+				var fallbackKeys []string
+				if s := tag.Get(tagFallbackKeys); s != "" {
+					fallbackKeys = strings.Split(s, ",")
+				}
+				if err := r.load(ctx, referenceClient, referenceNamespace(r.Namespace, component.GetNamespace()), ignoreNotFound, fallbackKeys...); err != nil {
+					return err
+				}
+				digestData["refs:"+string(rawPath)] = r.digest()
+			case *SecretReference:
+				ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
+				if err := r.load(ctx, referenceClient, referenceNamespace(r.Namespace, component.GetNamespace()), ignoreNotFound); err != nil {
+					return err
+				}
+				digestData["refs:"+string(rawPath)] = r.digest()
+			case *SecretKeyReference:
+				ignoreNotFound := !component.GetDeletionTimestamp().IsZero() && tag.Get(tagNotFoundPolicy) == notFoundPolicyIgnoreOnDeletion
+				var fallbackKeys []string
+				if s := tag.Get(tagFallbackKeys); s != "" {
+					fallbackKeys = strings.Split(s, ",")
+				}
+				if err := r.load(ctx, referenceClient, referenceNamespace(r.Namespace, component.GetNamespace()), ignoreNotFound, fallbackKeys...); err != nil {
+					return err
+				}
+				digestData["refs:"+string(rawPath)] = r.digest()
+			case Reference[T]:
+				// note: generic references are responsible for their own access checks
+				if err := r.Load(ctx, referenceHookClient, component); err != nil {
+					return err
+				}
+				digestData["refs:"+string(rawPath)] = r.Digest()
 			}
-			digestData["refs:"+string(rawPath)] = r.Digest()
+			return nil
+		}); err != nil {
+			return "", err
 		}
-		return nil
-	}); err != nil {
-		return "", err
 	}
 	return util.CalculateDigest(digestData), nil
 }
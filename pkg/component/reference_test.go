@@ -0,0 +1,138 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gobwas/glob"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// note: ginkgo cannot be dot-imported here because its Context would collide with this package's Context
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = ginkgo.Describe("testing: reference.go", func() {
+	var ctx context.Context
+	var localClient client.Client
+	var targetClient client.Client
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		localClient = newTestClient(
+			newTestSecret("default", "local", nil),
+			newTestSecret("other", "ungranted", nil),
+			newTestSecret("other", "granted", map[string]string{"test/reference-grant": "foo, default"}),
+			newTestSecret("shared-1", "shared", nil),
+		)
+		targetClient = newTestClient(
+			newTestSecret("default", "remote", nil),
+		)
+	})
+
+	resolve := func(component *testComponent, allowedNamespaces ...string) (string, error) {
+		var globs []glob.Glob
+		for _, pattern := range allowedNamespaces {
+			globs = append(globs, glob.MustCompile(pattern))
+		}
+		getTargetClient := func() (client.Client, error) {
+			return targetClient, nil
+		}
+		return resolveReferences(ctx, localClient, localClient, getTargetClient, component, globs, "test")
+	}
+
+	ginkgo.It("should load references from the component's namespace", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Name: "local"}
+		_, err := resolve(component)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.Spec.Secret.Data()).To(HaveKeyWithValue("key", []byte("local")))
+	})
+
+	ginkgo.It("should reject references into other namespaces without grant", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Namespace: "other", Name: "ungranted"}
+		_, err := resolve(component)
+		Expect(err).To(HaveOccurred())
+		Expect(errors.As(err, &types.RetriableError{})).To(BeTrue())
+	})
+
+	ginkgo.It("should not reveal whether objects exist in other namespaces without grant", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Namespace: "other", Name: "ungranted"}
+		_, errUngranted := resolve(component)
+		Expect(errUngranted).To(HaveOccurred())
+		component = newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Namespace: "other", Name: "missing"}
+		_, errMissing := resolve(component)
+		Expect(errMissing).To(HaveOccurred())
+		Expect(errors.As(errMissing, &types.RetriableError{})).To(BeTrue())
+		Expect(errMissing.Error()).To(Equal(strings.Replace(errUngranted.Error(), "ungranted", "missing", -1)))
+		Expect(errMissing.Error()).To(ContainSubstring("reference to other/missing is not permitted from namespace default"))
+	})
+
+	ginkgo.It("should report missing objects in allowed namespaces as not found", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Namespace: "shared-1", Name: "missing"}
+		_, err := resolve(component, "shared-*")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	ginkgo.It("should load references into other namespaces if granted by annotation", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Namespace: "other", Name: "granted"}
+		_, err := resolve(component)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.Spec.Secret.Data()).To(HaveKeyWithValue("key", []byte("granted")))
+	})
+
+	ginkgo.It("should load references into other namespaces if allowed by options", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Namespace: "shared-1", Name: "shared"}
+		_, err := resolve(component, "shared-*")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.Spec.Secret.Data()).To(HaveKeyWithValue("key", []byte("shared")))
+	})
+
+	ginkgo.It("should load target references through the target client", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.Secret = &SecretReference{Name: "local"}
+		component.Spec.TargetSecret = &SecretReference{Name: "remote"}
+		_, err := resolve(component)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.Spec.Secret.Data()).To(HaveKeyWithValue("key", []byte("local")))
+		Expect(component.Spec.TargetSecret.Data()).To(HaveKeyWithValue("key", []byte("remote")))
+	})
+
+	ginkgo.It("should include target references into the digest", func() {
+		component := newTestComponent("default", "test")
+		component.Spec.TargetSecret = &SecretReference{Name: "remote"}
+		digest1, err := resolve(component)
+		Expect(err).NotTo(HaveOccurred())
+		secret := &corev1.Secret{}
+		Expect(targetClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "remote"}, secret)).To(Succeed())
+		secret.Data["key"] = []byte("changed")
+		Expect(targetClient.Update(ctx, secret)).To(Succeed())
+		component = newTestComponent("default", "test")
+		component.Spec.TargetSecret = &SecretReference{Name: "remote"}
+		digest2, err := resolve(component)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest2).NotTo(Equal(digest1))
+	})
+
+	ginkgo.It("should reject invalid reference namespace patterns", func() {
+		r := NewReconciler[*testComponent]("test", nil, ReconcilerOptions{ReferenceNamespaces: []string{"shared-*", "["}})
+		Expect(r.setupReferenceNamespaces()).To(MatchError(ContainSubstring("invalid reference namespace pattern: [")))
+	})
+})
@@ -0,0 +1,283 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	legacyerrors "github.com/pkg/errors"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/sap/component-operator-runtime/internal/util"
+)
+
+// ShardingMode defines how components are distributed across the replicas of an operator.
+type ShardingMode string
+
+const (
+	// Components are assigned to shards by the label <reconciler-name>/shard; each replica serves exactly one shard.
+	// Components without that label belong to the shard with the empty name.
+	ShardingModeLabel ShardingMode = "Label"
+	// Components are assigned to the currently running replicas by consistent hashing of their namespace and name;
+	// replicas announce their membership by maintaining a lease in the cluster.
+	ShardingModeHash ShardingMode = "Hash"
+)
+
+const (
+	defaultShardLeaseDuration = 15 * time.Second
+	defaultShardRenewInterval = 5 * time.Second
+)
+
+// ShardingOptions configure the distribution of components across multiple replicas of an operator.
+// If sharding is enabled, the controller runs in all replicas (that is, it does not require leader election),
+// and each replica reconciles the subset of components it is responsible for.
+type ShardingOptions struct {
+	// Sharding mode; must be one of ShardingModeLabel and ShardingModeHash.
+	Mode ShardingMode
+	// Shard served by this replica (only relevant if Mode is ShardingModeLabel).
+	Shard string
+	// Identity of this replica (only relevant if Mode is ShardingModeHash); must be unique across all replicas.
+	// If unspecified, the hostname is used (which is the pod name if running in Kubernetes).
+	Identity string
+	// Namespace in which the membership leases are maintained (only relevant if Mode is ShardingModeHash).
+	// If unspecified, the namespace of the service account of the running pod is used.
+	LeaseNamespace string
+	// Duration after which the membership of a replica expires if its lease is not renewed (only relevant if Mode is ShardingModeHash).
+	// If unspecified, 15 seconds is assumed.
+	LeaseDuration time.Duration
+	// Interval in which the lease of this replica is renewed, and the membership is refreshed (only relevant if Mode is ShardingModeHash).
+	// If unspecified, 5 seconds is assumed.
+	RenewInterval time.Duration
+}
+
+// sharder decides whether a component is reconciled by this replica.
+type sharder interface {
+	isResponsible(object client.Object) bool
+}
+
+// labelSharder assigns components by the value of a label.
+type labelSharder struct {
+	labelKey string
+	shard    string
+}
+
+var _ sharder = &labelSharder{}
+
+func (s *labelSharder) isResponsible(object client.Object) bool {
+	return object.GetLabels()[s.labelKey] == s.shard
+}
+
+// hashSharder assigns components to the members of a group of replicas by rendezvous hashing;
+// each member maintains a lease (labeled with the group), and members whose lease was not renewed in time are considered to be gone.
+type hashSharder struct {
+	client        coordinationv1client.LeasesGetter
+	namespace     string
+	labelKey      string
+	group         string
+	identity      string
+	leaseName     string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	// called whenever the set of members changes
+	onChange func(ctx context.Context) error
+	mutex    sync.RWMutex
+	members  []string
+	// time of the last lease renewal of the last successful sync; members are considered stale once
+	// this is more than leaseDuration ago (since other replicas then consider this replica to be gone)
+	syncedAt time.Time
+}
+
+var _ sharder = &hashSharder{}
+var _ manager.LeaderElectionRunnable = &hashSharder{}
+
+func newHashSharder(clnt coordinationv1client.LeasesGetter, namespace string, labelKey string, group string, identity string, leaseDuration time.Duration, renewInterval time.Duration) *hashSharder {
+	return &hashSharder{
+		client:        clnt,
+		namespace:     namespace,
+		labelKey:      labelKey,
+		group:         group,
+		identity:      identity,
+		leaseName:     fmt.Sprintf("%s-%s", group, util.Sha256base32([]byte(labelKey + "/" + identity))[:16]),
+		leaseDuration: leaseDuration,
+		renewInterval: renewInterval,
+	}
+}
+
+// Implement manager.Runnable; renew the lease of this replica and refresh the members of the group, until the context is cancelled.
+func (s *hashSharder) Start(ctx context.Context) error {
+	log := log.FromContext(ctx).WithValues("shardGroup", s.group, "shardIdentity", s.identity)
+
+	ticker := time.NewTicker(s.renewInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.sync(ctx); err != nil {
+			log.Error(err, "error synchronizing shard membership")
+		}
+		select {
+		case <-ctx.Done():
+			// release the lease, such that other replicas take over the components of this replica without waiting for the lease to expire
+			if err := s.client.Leases(s.namespace).Delete(context.Background(), s.leaseName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "error releasing shard lease")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Implement manager.LeaderElectionRunnable; membership must be maintained in all replicas.
+func (s *hashSharder) NeedLeaderElection() bool {
+	return false
+}
+
+// Note: before the first successful sync (and if the membership could not be refreshed within the lease duration),
+// this replica is not responsible for any component.
+func (s *hashSharder) isResponsible(object client.Object) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if time.Since(s.syncedAt) > s.leaseDuration {
+		return false
+	}
+	return selectMember(s.members, object.GetNamespace()+"/"+object.GetName()) == s.identity
+}
+
+// Renew the lease of this replica, and refresh the list of members; call onChange if the members changed.
+// Leases of other members which expired a while ago (e.g. because the according replica crashed) are deleted.
+func (s *hashSharder) sync(ctx context.Context) error {
+	log := log.FromContext(ctx)
+
+	renewedAt := time.Now()
+	members, err := s.refresh(ctx)
+	if err != nil {
+		s.mutex.Lock()
+		if time.Since(s.syncedAt) > s.leaseDuration {
+			// note: other replicas consider this replica to be gone; so the components of this replica are probably served by others;
+			// clearing the members ensures that onChange is called once the membership could be refreshed again
+			s.members = nil
+		}
+		s.mutex.Unlock()
+		return err
+	}
+
+	s.mutex.Lock()
+	changed := !reflect.DeepEqual(members, s.members)
+	s.members = members
+	s.syncedAt = renewedAt
+	s.mutex.Unlock()
+
+	if changed {
+		log.V(1).Info("shard membership changed", "members", members)
+		if s.onChange != nil {
+			if err := s.onChange(ctx); err != nil {
+				return legacyerrors.Wrap(err, "error handling shard membership change")
+			}
+		}
+	}
+	return nil
+}
+
+// Renew the lease of this replica, and return the (sorted) list of current members.
+func (s *hashSharder) refresh(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+
+	if err := s.renew(ctx); err != nil {
+		return nil, legacyerrors.Wrap(err, "error renewing shard lease")
+	}
+
+	leaseList, err := s.client.Leases(s.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{s.labelKey: s.group}).String(),
+	})
+	if err != nil {
+		return nil, legacyerrors.Wrap(err, "error listing shard leases")
+	}
+	now := time.Now()
+	var members []string
+	for _, lease := range leaseList.Items {
+		if lease.Spec.HolderIdentity == nil || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		leaseDuration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		if expiry := lease.Spec.RenewTime.Add(leaseDuration); expiry.Before(now) {
+			// delete leases which expired more than one lease duration ago; the precondition ensures that the lease was not renewed in the meantime
+			if lease.Name != s.leaseName && expiry.Add(leaseDuration).Before(now) {
+				if err := s.client.Leases(s.namespace).Delete(ctx, lease.Name, metav1.DeleteOptions{
+					Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+				}); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+					log.Error(err, "error deleting expired shard lease", "lease", lease.Name)
+				}
+			}
+			continue
+		}
+		members = append(members, *lease.Spec.HolderIdentity)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+func (s *hashSharder) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(time.Now())
+	leaseDurationSeconds := int32(s.leaseDuration.Seconds())
+
+	lease, err := s.client.Leases(s.namespace).Get(ctx, s.leaseName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.namespace,
+				Name:      s.leaseName,
+				Labels:    map[string]string{s.labelKey: s.group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &s.identity,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err := s.client.Leases(s.namespace).Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	lease.Spec.HolderIdentity = &s.identity
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.RenewTime = &now
+	_, err = s.client.Leases(s.namespace).Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// Select the member responsible for the given key by rendezvous (highest random weight) hashing;
+// this ensures that, if members join or leave, only the keys of the affected members are reassigned.
+// Returns the empty string if there are no members.
+func selectMember(members []string, key string) string {
+	selected := ""
+	maxScore := uint64(0)
+	for _, member := range members {
+		hash := fnv.New64a()
+		hash.Write([]byte(member))
+		hash.Write([]byte{0})
+		hash.Write([]byte(key))
+		if score := hash.Sum64(); selected == "" || score > maxScore {
+			selected = member
+			maxScore = score
+		}
+	}
+	return selected
+}
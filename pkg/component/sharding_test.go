@@ -0,0 +1,132 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("testing: sharding.go", func() {
+	var ctx context.Context
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	newObject := func(namespace string, name string, labels map[string]string) client.Object {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+	}
+
+	ginkgo.It("should assign components by label", func() {
+		s := &labelSharder{labelKey: "test/shard", shard: "a"}
+		Expect(s.isResponsible(newObject("default", "test", map[string]string{"test/shard": "a"}))).To(BeTrue())
+		Expect(s.isResponsible(newObject("default", "test", map[string]string{"test/shard": "b"}))).To(BeFalse())
+		Expect(s.isResponsible(newObject("default", "test", nil))).To(BeFalse())
+		Expect((&labelSharder{labelKey: "test/shard"}).isResponsible(newObject("default", "test", nil))).To(BeTrue())
+	})
+
+	ginkgo.It("should only reassign keys of leaving members", func() {
+		before := make(map[string]string)
+		for i := 0; i < 1000; i++ {
+			key := fmt.Sprintf("default/test-%d", i)
+			before[key] = selectMember([]string{"a", "b", "c"}, key)
+		}
+		Expect(before).To(ContainElement("a"))
+		Expect(before).To(ContainElement("b"))
+		Expect(before).To(ContainElement("c"))
+		for key, member := range before {
+			after := selectMember([]string{"a", "c"}, key)
+			if member != "b" {
+				Expect(after).To(Equal(member))
+			} else {
+				Expect(after).NotTo(Equal("b"))
+			}
+		}
+		Expect(selectMember(nil, "default/test")).To(BeEmpty())
+	})
+
+	ginkgo.It("should partition components across the members of a group", func() {
+		clientset := fake.NewClientset()
+		numChanges := 0
+		newSharder := func(identity string) *hashSharder {
+			s := newHashSharder(clientset.CoordinationV1(), "default", "test/shard-group", "test", identity, 15*time.Second, 5*time.Second)
+			s.onChange = func(ctx context.Context) error {
+				numChanges++
+				return nil
+			}
+			return s
+		}
+		a := newSharder("a")
+		b := newSharder("b")
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(a.members).To(Equal([]string{"a"}))
+		Expect(b.sync(ctx)).To(Succeed())
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(a.members).To(Equal([]string{"a", "b"}))
+		Expect(b.members).To(Equal([]string{"a", "b"}))
+		Expect(numChanges).To(Equal(3))
+		Expect(a.sync(ctx)).To(Succeed())
+		Expect(numChanges).To(Equal(3))
+
+		for i := 0; i < 100; i++ {
+			object := newObject("default", fmt.Sprintf("test-%d", i), nil)
+			Expect(a.isResponsible(object)).NotTo(Equal(b.isResponsible(object)))
+		}
+
+		leaseList, err := clientset.CoordinationV1().Leases("default").List(ctx, metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaseList.Items).To(HaveLen(2))
+		lease := leaseList.Items[0]
+		lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now().Add(-time.Minute)}
+		_, err = clientset.CoordinationV1().Leases("default").Update(ctx, &lease, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		expired := *lease.Spec.HolderIdentity
+		remaining := map[string]*hashSharder{"a": a, "b": b}
+		delete(remaining, expired)
+		for _, s := range remaining {
+			Expect(s.sync(ctx)).To(Succeed())
+			Expect(s.members).To(Equal([]string{s.identity}))
+			Expect(s.isResponsible(newObject("default", "test", nil))).To(BeTrue())
+		}
+		leaseList, err = clientset.CoordinationV1().Leases("default").List(ctx, metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaseList.Items).To(HaveLen(1))
+		Expect(*leaseList.Items[0].Spec.HolderIdentity).NotTo(Equal(expired))
+	})
+
+	ginkgo.It("should not be responsible for any component unless the membership is current", func() {
+		clientset := fake.NewClientset()
+		s := newHashSharder(clientset.CoordinationV1(), "default", "test/shard-group", "test", "a", 15*time.Second, 5*time.Second)
+		object := newObject("default", "test", nil)
+		Expect(s.isResponsible(object)).To(BeFalse())
+		Expect(s.sync(ctx)).To(Succeed())
+		Expect(s.isResponsible(object)).To(BeTrue())
+
+		clientset.PrependReactor("*", "leases", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("api server not reachable")
+		})
+		Expect(s.sync(ctx)).NotTo(Succeed())
+		Expect(s.members).To(Equal([]string{"a"}))
+		Expect(s.isResponsible(object)).To(BeTrue())
+
+		s.syncedAt = time.Now().Add(-time.Minute)
+		Expect(s.isResponsible(object)).To(BeFalse())
+		Expect(s.sync(ctx)).NotTo(Succeed())
+		Expect(s.members).To(BeEmpty())
+	})
+})
@@ -7,14 +7,42 @@ package component
 
 import (
 	"context"
+	"fmt"
 
 	legacyerrors "github.com/pkg/errors"
+	"github.com/sap/go-generics/slices"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sap/component-operator-runtime/internal/util"
 	"github.com/sap/component-operator-runtime/pkg/cluster"
 	"github.com/sap/component-operator-runtime/pkg/manifests"
 	"github.com/sap/component-operator-runtime/pkg/reconciler"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+const (
+	eventReasonDeleteHooksSkipped = "DeleteHooksSkipped"
 )
 
+// componentTarget abstracts the cluster(s) the dependent objects of a component are deployed to.
+type componentTarget[T Component] interface {
+	Apply(ctx context.Context, component T, componentDigest string) (bool, error)
+	Plan(ctx context.Context, component T, componentDigest string, revision int64) (*Plan, error)
+	Validate(ctx context.Context, component T, componentDigest string, revision int64, dryRun bool) error
+	CollectOutputs(ctx context.Context) (map[string]string, map[string][]byte, error)
+	GetNotes() string
+	Test(ctx context.Context, component T, componentDigest string) (bool, string, error)
+	Delete(ctx context.Context, component T, componentDigest string) (bool, error)
+	IsDeletionAllowed(ctx context.Context, component T) (bool, string, error)
+}
+
+var _ componentTarget[Component] = &reconcileTarget[Component]{}
+
 type reconcileTarget[T Component] struct {
 	reconciler        *reconciler.Reconciler
 	reconcilerName    string
@@ -22,9 +50,23 @@ type reconcileTarget[T Component] struct {
 	localClient       cluster.Client
 	client            cluster.Client
 	resourceGenerator manifests.Generator
+	tracer            trace.Tracer
+	// name of the target (if the component is deployed to multiple targets); if empty, the inventory
+	// is maintained in the component's status, otherwise in the status of the according target
+	targetName string
+	// parameters deep-merged over the component's spec when rendering the manifests
+	parameterOverrides map[string]any
+	// reference to the secret containing the kubeconfig of the target (if known); recorded in the status of the target
+	kubeConfigSecretRef *SecretKeyReference
+	namespace           string
+	outputs             []manifests.Output
+	notes               string
 }
 
 func newReconcileTarget[T Component](reconcilerName string, reconcilerId string, localClient cluster.Client, clnt cluster.Client, resourceGenerator manifests.Generator, options reconciler.ReconcilerOptions) *reconcileTarget[T] {
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
 	return &reconcileTarget[T]{
 		reconcilerName:    reconcilerName,
 		reconcilerId:      reconcilerId,
@@ -32,11 +74,176 @@ func newReconcileTarget[T Component](reconcilerName string, reconcilerId string,
 		localClient:       localClient,
 		client:            clnt,
 		resourceGenerator: resourceGenerator,
+		tracer:            options.TracerProvider.Tracer(tracerName),
 	}
 }
 
 func (t *reconcileTarget[T]) Apply(ctx context.Context, component T, componentDigest string) (bool, error) {
 	//log := log.FromContext(ctx)
+	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+	status := component.GetStatus()
+
+	// TODO: componentDigest should always equal status.ProcessingDigest;
+	// so it should be ok to remove that parameter from the method
+	if componentDigest != status.ProcessingDigest {
+		panic("this cannot happen")
+	}
+
+	result, err := t.generate(ctx, component, componentDigest, status.Revision, true)
+	if err != nil {
+		return false, err
+	}
+	*t.getDeleteHooks(component) = slices.Any(result.objects, func(object client.Object) bool {
+		return object.GetAnnotations()[t.reconcilerName+"/"+types.AnnotationKeySuffixDeleteHook] != ""
+	})
+
+	// note: outputs and notes are retrieved with the same (effective) parameters which were used to render the manifests
+	t.namespace = result.namespace
+	t.outputs = nil
+	if outputGenerator, ok := t.resourceGenerator.(manifests.OutputGenerator); ok {
+		outputs, err := outputGenerator.GetOutputs(result.ctx, result.namespace, result.name, result.parameters)
+		if err != nil {
+			return false, legacyerrors.Wrap(err, "error getting outputs from generator")
+		}
+		t.outputs = append(t.outputs, outputs...)
+	}
+	outputs, err := getOutputsFromAnnotations(t.reconcilerName, result.objects)
+	if err != nil {
+		return false, legacyerrors.Wrap(err, "error getting outputs from dependent objects")
+	}
+	t.outputs = append(t.outputs, outputs...)
+
+	t.notes = result.notes
+
+	return t.reconciler.Apply(ctx, t.getInventory(component), result.objects, result.namespace, ownerId, componentDigest)
+}
+
+// Calculate the changes which an Apply() call (with the given component digest and revision) would perform,
+// without touching the target cluster or the component's inventory.
+func (t *reconcileTarget[T]) Plan(ctx context.Context, component T, componentDigest string, revision int64) (*Plan, error) {
+	result, err := t.generate(ctx, component, componentDigest, revision, false)
+	if err != nil {
+		return nil, err
+	}
+	items, err := t.reconciler.Plan(ctx, *t.getInventory(component), result.objects, result.namespace, componentDigest)
+	if err != nil {
+		return nil, legacyerrors.Wrap(err, "error calculating plan")
+	}
+	return &Plan{
+		Digest: util.CalculateDigest(componentDigest, items),
+		Items:  items,
+	}, nil
+}
+
+// Render the manifests of the component and validate the resulting objects (optionally by a server-side dry-run),
+// without touching the target cluster or the component's inventory.
+func (t *reconcileTarget[T]) Validate(ctx context.Context, component T, componentDigest string, revision int64, dryRun bool) error {
+	result, err := t.generate(ctx, component, componentDigest, revision, false)
+	if err != nil {
+		return err
+	}
+	if err := t.reconciler.Validate(ctx, result.objects, result.namespace, dryRun); err != nil {
+		return legacyerrors.Wrap(err, "error validating objects")
+	}
+	return nil
+}
+
+// Read the outputs declared during the preceding Apply() call from the according dependent objects.
+// Must only be called after Apply() returned true.
+func (t *reconcileTarget[T]) CollectOutputs(ctx context.Context) (map[string]string, map[string][]byte, error) {
+	return collectOutputs(ctx, t.client, t.namespace, t.outputs)
+}
+
+// Return the notes rendered during the preceding Apply() call.
+func (t *reconcileTarget[T]) GetNotes() string {
+	return t.notes
+}
+
+// Run the test hooks contained in the manifests of the component; must only be called after Apply() returned true.
+func (t *reconcileTarget[T]) Test(ctx context.Context, component T, componentDigest string) (bool, string, error) {
+	// log := log.FromContext(ctx)
+	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+	status := component.GetStatus()
+
+	result, err := t.generate(ctx, component, componentDigest, status.Revision, false)
+	if err != nil {
+		return false, "", err
+	}
+
+	return t.reconciler.Test(ctx, t.getInventory(component), result.objects, result.namespace, ownerId, componentDigest)
+}
+
+// Delete the dependent objects stored in the component's inventory; if the manifests which were last applied contained delete hooks,
+// the manifests of the component are rendered in order to run the delete hooks contained in them. If the manifests cannot be rendered
+// (or are invalid), an error is returned (such that the deletion is retried); delete hooks can be skipped explicitly by setting the annotation
+// <reconciler-name>/skip-delete-hooks to true on the component, in which case the dependent objects are deleted right away (and a warning event is emitted).
+// Failed delete hooks are not retried; the deletion remains in an error state (with reason DeleteHookFailed), until the above annotation is set.
+func (t *reconcileTarget[T]) Delete(ctx context.Context, component T, componentDigest string) (bool, error) {
+	// log := log.FromContext(ctx)
+	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+	status := component.GetStatus()
+
+	if skipDeleteHooksAnnotationKey := t.reconcilerName + "/" + types.AnnotationKeySuffixSkipDeleteHooks; component.GetAnnotations()[skipDeleteHooksAnnotationKey] == "true" {
+		if recorder, err := EventRecorderFromContext(ctx); err == nil {
+			recorder.Eventf(corev1.EventTypeWarning, eventReasonDeleteHooksSkipped, "Deleting dependent objects without running delete hooks (as requested by annotation %s)", skipDeleteHooksAnnotationKey)
+		}
+		return t.reconciler.Delete(ctx, t.getInventory(component), ownerId)
+	}
+	if !*t.getDeleteHooks(component) {
+		return t.reconciler.Delete(ctx, t.getInventory(component), ownerId)
+	}
+
+	result, err := t.generate(ctx, component, componentDigest, status.Revision, false)
+	if err != nil {
+		return false, err
+	}
+	if err := t.reconciler.Validate(ctx, result.objects, result.namespace, false); err != nil {
+		return false, legacyerrors.Wrap(err, "error validating objects")
+	}
+
+	inventory := t.getInventory(component)
+	ok, err := t.reconciler.DeleteWithHooks(ctx, inventory, result.objects, result.namespace, ownerId)
+	if err != nil && slices.Any(*inventory, func(item *reconciler.InventoryItem) bool { return item.Phase == reconciler.PhaseHookFailed }) {
+		return false, types.NewFailedError(
+			err,
+			ReadyConditionReasonDeleteHookFailed,
+			fmt.Sprintf("%s; set annotation %s to true to delete the component without running delete hooks", capitalize(err.Error()), t.reconcilerName+"/"+types.AnnotationKeySuffixSkipDeleteHooks),
+		)
+	}
+	return ok, err
+}
+
+// Delete the dependent objects stored in the component's inventory, without running any delete hooks;
+// this is used to clean up targets which were removed from the component.
+func (t *reconcileTarget[T]) Purge(ctx context.Context, component T) (bool, error) {
+	// log := log.FromContext(ctx)
+	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+
+	return t.reconciler.Delete(ctx, t.getInventory(component), ownerId)
+}
+
+func (t *reconcileTarget[T]) IsDeletionAllowed(ctx context.Context, component T) (bool, string, error) {
+	// log := log.FromContext(ctx)
+	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+
+	return t.reconciler.IsDeletionAllowed(ctx, t.getInventory(component), ownerId)
+}
+
+// Result of rendering the manifests of a component for a target.
+type generateResult struct {
+	objects []client.Object
+	// context, namespace, name and (effective) parameters passed to the generator
+	ctx        Context
+	namespace  string
+	name       string
+	parameters types.Unstructurable
+	// notes rendered along with the manifests (only populated if requested)
+	notes string
+}
+
+// Render the manifests of the component for this target, with the parameter overrides of the target merged over the component's spec;
+// if withNotes is true, and the generator provides notes, these are rendered as well (if possible, in the same pass).
+func (t *reconcileTarget[T]) generate(ctx context.Context, component T, componentDigest string, revision int64, withNotes bool) (*generateResult, error) {
 	namespace := ""
 	name := ""
 	if placementConfiguration, ok := assertPlacementConfiguration(component); ok {
@@ -49,14 +256,6 @@ func (t *reconcileTarget[T]) Apply(ctx context.Context, component T, componentDi
 	if name == "" {
 		name = component.GetName()
 	}
-	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
-	status := component.GetStatus()
-
-	// TODO: componentDigest should always equal status.ProcessingDigest;
-	// so it should be ok to remove that parameter from the method
-	if componentDigest != status.ProcessingDigest {
-		panic("this cannot happen")
-	}
 
 	// TODO: enhance ctx with local client
 	generateCtx := NewContext(ctx).
@@ -67,27 +266,57 @@ func (t *reconcileTarget[T]) Apply(ctx context.Context, component T, componentDi
 		WithComponentName(component.GetName()).
 		WithComponentNamespace(component.GetNamespace()).
 		WithComponentDigest(componentDigest).
-		WithComponentRevision(status.Revision)
-	objects, err := t.resourceGenerator.Generate(generateCtx, namespace, name, component.GetSpec())
+		WithComponentRevision(revision)
+	parameters := component.GetSpec()
+	if len(t.parameterOverrides) > 0 {
+		parameters = types.UnstructurableMap(manifests.MergeMaps(parameters.ToUnstructured(), t.parameterOverrides))
+	}
+	// note: the span is only passed to the generator; the returned context is not derived from it
+	spanCtx, span := t.tracer.Start(generateCtx, "Generate", trace.WithAttributes(attribute.String("component.target", t.targetName)))
+	var objects []client.Object
+	var notes string
+	var err error
+	switch generator := t.resourceGenerator.(type) {
+	case manifests.CombinedNotesGenerator:
+		if withNotes {
+			objects, notes, err = generator.GenerateWithNotes(spanCtx, namespace, name, parameters)
+		} else {
+			objects, err = generator.Generate(spanCtx, namespace, name, parameters)
+		}
+	case manifests.NotesGenerator:
+		objects, err = generator.Generate(spanCtx, namespace, name, parameters)
+		if err == nil && withNotes {
+			notes, err = generator.GetNotes(spanCtx, namespace, name, parameters)
+		}
+	default:
+		objects, err = generator.Generate(spanCtx, namespace, name, parameters)
+	}
+	util.EndSpan(span, err)
 	if err != nil {
-		return false, legacyerrors.Wrap(err, "error rendering manifests")
+		return nil, legacyerrors.Wrap(err, "error rendering manifests")
 	}
-
-	return t.reconciler.Apply(ctx, &status.Inventory, objects, namespace, ownerId, componentDigest)
+	return &generateResult{
+		objects:    objects,
+		ctx:        generateCtx,
+		namespace:  namespace,
+		name:       name,
+		parameters: parameters,
+		notes:      notes,
+	}, nil
 }
 
-func (t *reconcileTarget[T]) Delete(ctx context.Context, component T) (bool, error) {
-	// log := log.FromContext(ctx)
-	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+func (t *reconcileTarget[T]) getInventory(component T) *[]*reconciler.InventoryItem {
 	status := component.GetStatus()
-
-	return t.reconciler.Delete(ctx, &status.Inventory, ownerId)
+	if t.targetName == "" {
+		return &status.Inventory
+	}
+	return &status.getOrAddTarget(t.targetName).Inventory
 }
 
-func (t *reconcileTarget[T]) IsDeletionAllowed(ctx context.Context, component T) (bool, string, error) {
-	// log := log.FromContext(ctx)
-	ownerId := t.reconcilerId + "/" + component.GetNamespace() + "/" + component.GetName()
+func (t *reconcileTarget[T]) getDeleteHooks(component T) *bool {
 	status := component.GetStatus()
-
-	return t.reconciler.IsDeletionAllowed(ctx, &status.Inventory, ownerId)
+	if t.targetName == "" {
+		return &status.DeleteHooks
+	}
+	return &status.getOrAddTarget(t.targetName).DeleteHooks
 }
@@ -0,0 +1,333 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/internal/events"
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/reconciler"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = ginkgo.Describe("testing: target.go", func() {
+	var ctx context.Context
+	var component *testComponent
+	var exporter *tracetest.InMemoryExporter
+	var tracerProvider *sdktrace.TracerProvider
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		component = newTestComponent("default", "test")
+		component.Status.ProcessingDigest = "digest"
+		exporter = tracetest.NewInMemoryExporter()
+		tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	})
+
+	ginkgo.It("should record spans for generation, apply waves and object operations", func() {
+		clnt := cluster.NewClient(newTestClient(), nil, record.NewFakeRecorder(100), nil, nil)
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, &testGenerator{}, reconciler.ReconcilerOptions{TracerProvider: tracerProvider})
+
+		ctx, span := tracerProvider.Tracer("test").Start(ctx, "Test")
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		span.End()
+
+		spans := exporter.GetSpans()
+		names := make(map[string]trace.SpanID)
+		for _, span := range spans {
+			Expect(span.SpanContext.TraceID()).To(Equal(spans[len(spans)-1].SpanContext.TraceID()))
+			names[span.Name] = span.Parent.SpanID()
+		}
+		Expect(names).To(HaveKey("Generate"))
+		Expect(names).To(HaveKey("ApplyWave"))
+		Expect(names).To(HaveKey("CreateObject"))
+		Expect(names["Generate"]).To(Equal(span.SpanContext().SpanID()))
+		Expect(names["ApplyWave"]).To(Equal(span.SpanContext().SpanID()))
+	})
+
+	ginkgo.It("should run test hooks after apply, and re-run them if the component digest changes", func() {
+		testClient := newTestClient()
+		clnt := cluster.NewClient(testClient, nil, record.NewFakeRecorder(100), nil, nil)
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, &testHookGenerator{}, reconciler.ReconcilerOptions{})
+		pod := &corev1.Pod{}
+		podKey := client.ObjectKey{Namespace: "default", Name: "test-test"}
+		setPodPhase := func(phase corev1.PodPhase) {
+			Expect(testClient.Get(ctx, podKey, pod)).To(Succeed())
+			pod.Status.Phase = phase
+			Expect(testClient.Status().Update(ctx, pod)).To(Succeed())
+		}
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(apierrors.IsNotFound(testClient.Get(ctx, podKey, pod))).To(BeTrue())
+
+		// first call adds the test to the inventory, second call creates the test object
+		for range 2 {
+			tested, msg, err := target.Test(ctx, component, "digest")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tested).To(BeFalse())
+			Expect(msg).To(BeEmpty())
+		}
+		Expect(testClient.Get(ctx, podKey, pod)).To(Succeed())
+
+		setPodPhase(corev1.PodSucceeded)
+		tested, msg, err := target.Test(ctx, component, "digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tested).To(BeTrue())
+		Expect(msg).To(BeEmpty())
+
+		// a change of the component digest must not cause any changes to the test when applying, but trigger a re-run of the test
+		component.Status.ProcessingDigest = "digest2"
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest2") }).Should(BeTrue())
+		// first call deletes the previous test object, second call re-creates it
+		for range 2 {
+			tested, msg, err := target.Test(ctx, component, "digest2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tested).To(BeFalse())
+			Expect(msg).To(BeEmpty())
+		}
+		Expect(testClient.Get(ctx, podKey, pod)).To(Succeed())
+		Expect(pod.Status.Phase).To(BeEmpty())
+
+		setPodPhase(corev1.PodFailed)
+		tested, msg, err = target.Test(ctx, component, "digest2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tested).To(BeTrue())
+		Expect(msg).To(ContainSubstring("test(s) failed"))
+	})
+
+	ginkgo.It("should keep the plan digest stable when the plan is approved, such that apply can proceed", func() {
+		testClient := newTestClient()
+		clnt := cluster.NewClient(testClient, nil, record.NewFakeRecorder(100), nil, nil)
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, &testGenerator{}, reconciler.ReconcilerOptions{})
+		getTargetClient := func() (client.Client, error) { return testClient, nil }
+
+		componentDigest, err := resolveReferences(ctx, testClient, testClient, getTargetClient, component, nil, "test")
+		Expect(err).NotTo(HaveOccurred())
+		component.Status.ProcessingDigest = componentDigest
+		plan, err := target.Plan(ctx, component, componentDigest, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.isEmpty()).To(BeFalse())
+
+		component.SetAnnotations(map[string]string{"test/" + types.AnnotationKeySuffixApprovedPlan: plan.Digest})
+		approvedComponentDigest, err := resolveReferences(ctx, testClient, testClient, getTargetClient, component, nil, "test")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approvedComponentDigest).To(Equal(componentDigest))
+		approvedPlan, err := target.Plan(ctx, component, approvedComponentDigest, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approvedPlan.Digest).To(Equal(plan.Digest))
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, approvedComponentDigest) }).Should(BeTrue())
+		Expect(component.Status.Inventory).NotTo(BeEmpty())
+	})
+
+	ginkgo.It("should render notes and outputs with the parameter overrides of the target, in the same pass as the manifests", func() {
+		clnt := cluster.NewClient(newTestClient(), nil, record.NewFakeRecorder(100), nil, nil)
+		generator := &testNotesGenerator{}
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, generator, reconciler.ReconcilerOptions{})
+		target.targetName = "a"
+		target.parameterOverrides = map[string]any{"target": "a"}
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(target.GetNotes()).To(Equal("deployed to a"))
+		outputs, _, err := target.CollectOutputs(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs).To(HaveKey("a"))
+		numRendered := generator.numRendered
+		_, err = target.Apply(ctx, component, "digest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generator.numRendered).To(Equal(numRendered + 1))
+	})
+
+	ginkgo.It("should not delete dependent objects if the manifests containing delete hooks cannot be rendered", func() {
+		testClient := newTestClient()
+		clnt := cluster.NewClient(testClient, nil, record.NewFakeRecorder(100), nil, nil)
+		generator := &testStaticGenerator{objects: []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-hook",
+				Annotations: map[string]string{"test/" + types.AnnotationKeySuffixDeleteHook: types.DeleteHookPreDelete},
+			}},
+		}}
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, generator, reconciler.ReconcilerOptions{})
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.DeleteHooks).To(BeTrue())
+
+		generator.err = errors.New("chart not available")
+		_, err := target.Delete(ctx, component, "digest")
+		Expect(err).To(MatchError(ContainSubstring("chart not available")))
+		Expect(component.Status.Inventory).NotTo(BeEmpty())
+		Expect(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).To(Succeed())
+	})
+
+	ginkgo.It("should delete dependent objects without rendering the manifests if they contain no delete hooks", func() {
+		testClient := newTestClient()
+		clnt := cluster.NewClient(testClient, nil, record.NewFakeRecorder(100), nil, nil)
+		generator := &testStaticGenerator{objects: []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		}}
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, generator, reconciler.ReconcilerOptions{})
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.DeleteHooks).To(BeFalse())
+
+		generator.err = errors.New("chart not available")
+		Eventually(func() (bool, error) { return target.Delete(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.Inventory).To(BeEmpty())
+		Expect(apierrors.IsNotFound(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	ginkgo.It("should not retry failed delete hooks, even if they are recreated upon update", func() {
+		testClient := newTestClient()
+		clnt := cluster.NewClient(testClient, nil, record.NewFakeRecorder(100), nil, nil)
+		generator := &testStaticGenerator{objects: []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			&batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-hook",
+					Annotations: map[string]string{
+						"test/" + types.AnnotationKeySuffixDeleteHook:   types.DeleteHookPreDelete,
+						"test/" + types.AnnotationKeySuffixUpdatePolicy: types.UpdatePolicyRecreate,
+					},
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers:    []corev1.Container{{Name: "test", Image: "busybox"}},
+							RestartPolicy: corev1.RestartPolicyNever,
+						},
+					},
+				},
+			},
+		}}
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, generator, reconciler.ReconcilerOptions{})
+		job := &batchv1.Job{}
+		jobKey := client.ObjectKey{Namespace: "default", Name: "test-hook"}
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+
+		// first call adds the hook to the inventory, second call creates the hook object
+		for range 2 {
+			deleted, err := target.Delete(ctx, component, "digest")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		}
+		Expect(testClient.Get(ctx, jobKey, job)).To(Succeed())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+		Expect(testClient.Status().Update(ctx, job)).To(Succeed())
+
+		for range 3 {
+			_, err := target.Delete(ctx, component, "digest")
+			reconcileError := types.ReconcileError{}
+			Expect(errors.As(err, &reconcileError)).To(BeTrue())
+			Expect(reconcileError.State()).To(Equal(types.ErrorStateError))
+			Expect(reconcileError.Reason()).To(Equal(ReadyConditionReasonDeleteHookFailed))
+			Expect(reconcileError.Message()).To(ContainSubstring(types.AnnotationKeySuffixSkipDeleteHooks))
+			Expect(apierrors.IsNotFound(testClient.Get(ctx, jobKey, job))).To(BeTrue())
+			Expect(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).To(Succeed())
+		}
+
+		component.SetAnnotations(map[string]string{"test/" + types.AnnotationKeySuffixSkipDeleteHooks: "true"})
+		Eventually(func() (bool, error) { return target.Delete(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.Inventory).To(BeEmpty())
+		Expect(apierrors.IsNotFound(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}))).To(BeTrue())
+	})
+
+	ginkgo.It("should delete dependent objects without running delete hooks if requested by annotation", func() {
+		testClient := newTestClient()
+		clnt := cluster.NewClient(testClient, nil, record.NewFakeRecorder(100), nil, nil)
+		generator := &testStaticGenerator{objects: []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-hook",
+				Annotations: map[string]string{"test/" + types.AnnotationKeySuffixDeleteHook: types.DeleteHookPreDelete},
+			}},
+		}}
+		target := newReconcileTarget[*testComponent]("test", "test", clnt, clnt, generator, reconciler.ReconcilerOptions{})
+		recorder := record.NewFakeRecorder(100)
+		ctx := NewContext(ctx).WithEventRecorder(newEventRecorder(events.NewDeduplicatingRecorder(recorder, time.Minute), component))
+
+		Eventually(func() (bool, error) { return target.Apply(ctx, component, "digest") }).Should(BeTrue())
+		Expect(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).To(Succeed())
+
+		generator.err = errors.New("chart not available")
+		component.SetAnnotations(map[string]string{"test/" + types.AnnotationKeySuffixSkipDeleteHooks: "true"})
+		Eventually(func() (bool, error) { return target.Delete(ctx, component, "digest") }).Should(BeTrue())
+		Expect(component.Status.Inventory).To(BeEmpty())
+		Expect(apierrors.IsNotFound(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{}))).To(BeTrue())
+		Expect(apierrors.IsNotFound(testClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-hook"}, &corev1.ConfigMap{}))).To(BeTrue())
+		Expect(recorder.Events).To(Receive(HavePrefix(corev1.EventTypeWarning + " " + eventReasonDeleteHooksSkipped)))
+	})
+})
+
+type testNotesGenerator struct {
+	testGenerator
+	numRendered int
+}
+
+var _ manifests.OutputGenerator = &testNotesGenerator{}
+var _ manifests.CombinedNotesGenerator = &testNotesGenerator{}
+
+func (g *testNotesGenerator) GenerateWithNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, string, error) {
+	g.numRendered++
+	objects, err := g.Generate(ctx, namespace, name, parameters)
+	if err != nil {
+		return nil, "", err
+	}
+	return objects, fmt.Sprintf("deployed to %s", parameters.ToUnstructured()["target"]), nil
+}
+
+func (g *testNotesGenerator) GetNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) (string, error) {
+	g.numRendered++
+	return fmt.Sprintf("deployed to %s", parameters.ToUnstructured()["target"]), nil
+}
+
+func (g *testNotesGenerator) GetOutputs(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]manifests.Output, error) {
+	return []manifests.Output{{
+		Name:   fmt.Sprintf("%s", parameters.ToUnstructured()["target"]),
+		Object: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+		Path:   "{.metadata.name}",
+	}}, nil
+}
+
+type testHookGenerator struct{}
+
+func (g *testHookGenerator) Generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, error) {
+	return []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name + "-test",
+				Annotations: map[string]string{"test/" + types.AnnotationKeySuffixTestHook: "true"},
+			},
+			Spec: corev1.PodSpec{
+				Containers:    []corev1.Container{{Name: "test", Image: "busybox"}},
+				RestartPolicy: corev1.RestartPolicyNever,
+			},
+		},
+	}, nil
+}
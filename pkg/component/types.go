@@ -8,6 +8,7 @@ package component
 import (
 	"time"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -87,6 +88,17 @@ type RetryConfiguration interface {
 	GetRetryInterval() time.Duration
 }
 
+// The BackoffConfiguration interface is meant to be implemented by components (or their spec) which offer
+// tweaking the backoff applied while waiting for dependent objects to become ready (or to be deleted).
+type BackoffConfiguration interface {
+	// Get the maximum backoff delay. Components with a higher value are requeued less frequently while processing,
+	// which reduces the load caused by slow or noisy components.
+	// A return value of zero means to use the framework default (or the rate limiter specified in the reconciler options).
+	// Values are clamped to the range between 1 second and 1 hour; if the reconciler options specify a custom rate limiter,
+	// the returned value only caps the delays calculated by that rate limiter.
+	GetMaxBackoff() time.Duration
+}
+
 // The TimeoutConfiguration interface is meant to be implemented by components (or their spec) which offer
 // tweaking the processing timeout (by default, it would be the value of the requeue interval).
 type TimeoutConfiguration interface {
@@ -132,6 +144,95 @@ type ReapplyConfiguration interface {
 	GetReapplyInterval() time.Duration
 }
 
+// The OutputConfiguration interface is meant to be implemented by components (or their spec) which want
+// their outputs (declared by the generator, or by annotations on dependent objects) to be written into a connection secret.
+type OutputConfiguration interface {
+	// Get the name of the connection secret (in the component's namespace) the outputs are written to.
+	// The secret will be owned by the component. If the empty string is returned, no secret will be written.
+	GetConnectionSecretName() string
+}
+
+// The MaintenanceWindowConfiguration interface is meant to be implemented by components (or their spec) which want
+// changes to be rolled out only within certain maintenance windows.
+type MaintenanceWindowConfiguration interface {
+	// Get maintenance windows. Outside of these windows, changes to the component (that is, changes of the component's digest)
+	// are not applied; instead the component is held in Pending state until the next window starts.
+	// If no windows are returned, changes are applied at any time.
+	GetMaintenanceWindows() []MaintenanceWindow
+	// Get the policy for drift correction (that is, reconciling dependent objects of an unchanged, ready component)
+	// outside of maintenance windows. Must return a valid MaintenanceWindowPolicy, or the empty string (then MaintenanceWindowPolicyAllow applies).
+	GetMaintenanceWindowDriftCorrectionPolicy() MaintenanceWindowPolicy
+	// Get the policy for deletion outside of maintenance windows.
+	// Must return a valid MaintenanceWindowPolicy, or the empty string (then MaintenanceWindowPolicyAllow applies).
+	GetMaintenanceWindowDeletionPolicy() MaintenanceWindowPolicy
+}
+
+// The MultiTargetConfiguration interface is meant to be implemented by components (or their spec) which want
+// to be deployed to several target clusters at once.
+type MultiTargetConfiguration interface {
+	// Get target clusters. If a non-empty list is returned, the component's dependent objects are deployed
+	// to each of the returned targets (and ClientConfiguration, ImpersonationConfiguration are ignored for that purpose).
+	// Target names must be unique. Dependent objects of targets which are removed from the list are deleted (without running delete hooks),
+	// before changes are rolled out to the remaining targets; this requires the kubeconfig of the removed target to be known, that is, it is either
+	// still remembered by the reconciler, or it can be loaded from the secret recorded in the target's status (see Target.KubeConfigSecretRef).
+	// Otherwise the removed target remains in the component's status (in error state, retaining its inventory) until its kubeconfig becomes available again.
+	GetTargets() ([]Target, error)
+	// Get the strategy used to roll out changes across the targets.
+	GetRolloutStrategy() RolloutStrategy
+}
+
+// Target describes a cluster a component is deployed to.
+type Target struct {
+	// Name of the target; must be unique among the targets of the component.
+	Name string
+	// Kubeconfig of the target cluster.
+	KubeConfig []byte
+	// Reference to the secret containing the kubeconfig (optional); if set, the reference is recorded in the status of the target,
+	// such that the dependent objects can still be deleted after the target was removed, even if the controller was restarted in the meantime.
+	KubeConfigSecretRef *SecretKeyReference
+	// Parameters which are deep-merged over the component's spec when rendering the manifests for this target.
+	ParameterOverrides map[string]any
+}
+
+// RolloutStrategyType defines how changes are rolled out across multiple targets.
+type RolloutStrategyType string
+
+const (
+	// Roll out changes to all targets in parallel.
+	RolloutStrategyTypeAllAtOnce RolloutStrategyType = "AllAtOnce"
+	// Roll out changes to one target after another; a target is started only if all previous targets are ready.
+	RolloutStrategyTypeOneAtATime RolloutStrategyType = "OneAtATime"
+	// Roll out changes to multiple targets in parallel, such that at most the specified number of targets is not ready.
+	RolloutStrategyTypeMaxUnavailable RolloutStrategyType = "MaxUnavailable"
+)
+
+// The ApprovalConfiguration interface is meant to be implemented by components (or their spec) which want
+// changes to be approved before they are applied.
+type ApprovalConfiguration interface {
+	// Whether changes must be approved. If true, then, whenever the component's digest changes, a plan (listing the dependent objects
+	// which are about to be created, updated or deleted) is calculated and stored in the component's status; the plan will only be applied
+	// once the annotation <reconciler-name>/approved-plan is set on the component, with a value matching the digest of the plan.
+	IsApprovalRequired() bool
+}
+
+// The TestConfiguration interface is meant to be implemented by components (or their spec) which want the test hooks
+// of their dependent objects (that is, objects annotated with <reconciler-name>/test-hook) to be run.
+type TestConfiguration interface {
+	// Whether tests are run. If true, then the test hooks are run whenever the component became ready after a revision change,
+	// and the outcome is recorded as condition 'Tested' in the component's status.
+	IsTestEnabled() bool
+}
+
+// MaintenanceWindowPolicy defines whether an operation is allowed or held outside of maintenance windows.
+type MaintenanceWindowPolicy string
+
+const (
+	// Allow the operation outside of maintenance windows.
+	MaintenanceWindowPolicyAllow MaintenanceWindowPolicy = "Allow"
+	// Hold the operation until the next maintenance window starts.
+	MaintenanceWindowPolicyHold MaintenanceWindowPolicy = "Hold"
+)
+
 // +kubebuilder:object:generate=true
 
 // Legacy placement spec. Components may include this into their spec.
@@ -212,6 +313,18 @@ var _ RetryConfiguration = &RetrySpec{}
 
 // +kubebuilder:object:generate=true
 
+// BackoffSpec defines the maximum backoff delay applied while waiting for dependent objects to become ready (or to be deleted).
+// Components providing BackoffConfiguration may include this into their spec.
+type BackoffSpec struct {
+	// +kubebuilder:validation:Type:=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+var _ BackoffConfiguration = &BackoffSpec{}
+
+// +kubebuilder:object:generate=true
+
 // TimeoutSpec defines the processing timeout, that is, the duration after which all dependent objects of the component
 // must have reached a ready state, or the component status will change to error.
 // Components providing TimeoutConfiguration may include this into their spec.
@@ -262,6 +375,127 @@ var _ ReapplyConfiguration = &ReapplySpec{}
 
 // +kubebuilder:object:generate=true
 
+// OutputSpec allows to specify the name of a connection secret, to which the component's outputs are written.
+// Components providing OutputConfiguration may include this into their spec.
+type OutputSpec struct {
+	ConnectionSecretName string `json:"connectionSecretName,omitempty"`
+}
+
+var _ OutputConfiguration = &OutputSpec{}
+
+// +kubebuilder:object:generate=true
+
+// MaintenanceWindow defines a recurring time range.
+type MaintenanceWindow struct {
+	// Days of the week on which the window starts; if empty, the window starts every day.
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Days []string `json:"days,omitempty"`
+	// Start time of the window (in the format HH:MM).
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration of the window; must not exceed seven days.
+	// +kubebuilder:validation:Type:=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Duration metav1.Duration `json:"duration"`
+	// Time zone (IANA name, such as Europe/Berlin) in which start is interpreted; if empty, UTC is assumed.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// MaintenanceWindowSpec defines maintenance windows, and how drift correction and deletion are handled outside of these windows.
+// Components providing MaintenanceWindowConfiguration may include this into their spec.
+type MaintenanceWindowSpec struct {
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// +kubebuilder:validation:Enum=Allow;Hold
+	MaintenanceWindowDriftCorrectionPolicy MaintenanceWindowPolicy `json:"maintenanceWindowDriftCorrectionPolicy,omitempty"`
+	// +kubebuilder:validation:Enum=Allow;Hold
+	MaintenanceWindowDeletionPolicy MaintenanceWindowPolicy `json:"maintenanceWindowDeletionPolicy,omitempty"`
+}
+
+var _ MaintenanceWindowConfiguration = &MaintenanceWindowSpec{}
+
+// +kubebuilder:object:generate=true
+
+// RolloutStrategy defines how changes are rolled out across multiple targets.
+type RolloutStrategy struct {
+	// Type of the rollout strategy; if empty, AllAtOnce is assumed.
+	// +kubebuilder:validation:Enum=AllAtOnce;OneAtATime;MaxUnavailable
+	Type RolloutStrategyType `json:"type,omitempty"`
+	// Maximum number of targets which may be processed at the same time; only relevant for type MaxUnavailable.
+	// +kubebuilder:validation:Minimum=1
+	MaxUnavailable int `json:"maxUnavailable,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// TargetSpec defines a target cluster (by kubeconfig), and optional parameter overrides.
+type TargetSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	Name       string         `json:"name"`
+	KubeConfig KubeConfigSpec `json:"kubeConfig"`
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ParameterOverrides *apiextensionsv1.JSON `json:"parameterOverrides,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// MultiTargetSpec defines a list of target clusters, and the strategy used to roll out changes across them.
+// Components providing MultiTargetConfiguration may include this into their spec.
+type MultiTargetSpec struct {
+	// +listType=map
+	// +listMapKey=name
+	Targets         []TargetSpec    `json:"targets,omitempty"`
+	RolloutStrategy RolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+var _ MultiTargetConfiguration = &MultiTargetSpec{}
+
+// +kubebuilder:object:generate=true
+
+// ApprovalSpec defines whether changes must be approved before they are applied.
+// Components providing ApprovalConfiguration may include this into their spec.
+type ApprovalSpec struct {
+	RequireApproval bool `json:"requireApproval,omitempty"`
+}
+
+var _ ApprovalConfiguration = &ApprovalSpec{}
+
+// +kubebuilder:object:generate=true
+
+// TestSpec defines whether the test hooks of the component are run.
+// Components providing TestConfiguration may include this into their spec.
+type TestSpec struct {
+	RunTests bool `json:"runTests,omitempty"`
+}
+
+var _ TestConfiguration = &TestSpec{}
+
+// +kubebuilder:object:generate=true
+
+// Plan represents the changes to the dependent objects which are about to be performed for a new component digest.
+type Plan struct {
+	// Digest of the plan; approvals must reference this value.
+	Digest string `json:"digest"`
+	// Planned changes.
+	Items []reconciler.PlanItem `json:"items,omitempty"`
+	// Planned changes per target; only populated for components which are deployed to multiple targets.
+	Targets []TargetPlan `json:"targets,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// TargetPlan represents the changes to the dependent objects which are about to be performed on a specific target.
+type TargetPlan struct {
+	// Name of the target.
+	Name string `json:"name"`
+	// Planned changes.
+	Items []reconciler.PlanItem `json:"items,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
 // Component Status. Components must include this into their status.
 type Status struct {
 	ObservedGeneration   int64        `json:"observedGeneration"`
@@ -273,9 +507,53 @@ type Status struct {
 	LastProcessingDigest string       `json:"lastProcessingDigest,omitempty"`
 	Revision             int64        `json:"revision,omitempty"`
 	Conditions           []Condition  `json:"conditions,omitempty"`
-	// +kubebuilder:validation:Enum=Ready;Pending;Processing;DeletionPending;Deleting;Error
+	// +kubebuilder:validation:Enum=Ready;Pending;Processing;DeletionPending;Deleting;Error;Stalled
 	State     State                       `json:"state,omitempty"`
 	Inventory []*reconciler.InventoryItem `json:"inventory,omitempty"`
+	// Non-sensitive outputs of the component, as declared by the generator, or by annotations on dependent objects;
+	// populated whenever the component became ready.
+	Outputs map[string]string `json:"outputs,omitempty"`
+	// Name of the connection secret which was last written; used to clean up the secret if the connection secret name changes.
+	ConnectionSecretName string `json:"connectionSecretName,omitempty"`
+	// Usage notes of the component, as rendered by the generator (such as the NOTES.txt of a Helm chart);
+	// populated whenever the component became ready.
+	Notes string `json:"notes,omitempty"`
+	// Plan calculated for the current component digest; only populated if the component requires approval of changes,
+	// and cleared once the plan was successfully applied.
+	Plan *Plan `json:"plan,omitempty"`
+	// Status of the individual targets; only populated for components which are deployed to multiple targets.
+	Targets []TargetStatus `json:"targets,omitempty"`
+	// Number of consecutive reconciliation failures with the same error; only maintained if the circuit breaker is enabled.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+	// Timestamp when the component entered the Stalled state.
+	StalledSince *metav1.Time `json:"stalledSince,omitempty"`
+	// Value of the retry annotation which was last handled by the reconciler.
+	LastHandledRetry string `json:"lastHandledRetry,omitempty"`
+	// Whether the manifests which were last applied contain delete hooks; only then, the manifests are rendered again upon deletion.
+	DeleteHooks bool `json:"deleteHooks,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// TargetStatus represents the status of a component on a specific target.
+type TargetStatus struct {
+	// Name of the target.
+	Name string `json:"name"`
+	// State of the component on this target.
+	// +kubebuilder:validation:Enum=Ready;Pending;Processing;Deleting;Error
+	State State `json:"state,omitempty"`
+	// Details about the state, such as an error message.
+	Message string `json:"message,omitempty"`
+	// Component digest which was last rolled out (or is being rolled out) to this target.
+	ProcessingDigest string `json:"processingDigest,omitempty"`
+	// Timestamp when the dependent objects were last successfully reconciled on this target.
+	LastAppliedAt *metav1.Time `json:"lastAppliedAt,omitempty"`
+	// Reference to the secret containing the kubeconfig of this target; used to delete the dependent objects after the target was removed.
+	KubeConfigSecretRef *SecretKeyReference `json:"kubeConfigSecretRef,omitempty"`
+	// Whether the manifests which were last applied to this target contain delete hooks.
+	DeleteHooks bool `json:"deleteHooks,omitempty"`
+	// Dependent objects on this target.
+	Inventory []*reconciler.InventoryItem `json:"inventory,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -293,12 +571,14 @@ type Condition struct {
 	Message string `json:"message,omitempty"`
 }
 
-// Condition type. Currently, only the 'Ready' type is used.
+// Condition type. Can be one of 'Ready', 'Tested'.
 type ConditionType string
 
 const (
 	// Condition type representing the 'Ready' condition.
 	ConditionTypeReady ConditionType = "Ready"
+	// Condition type representing the 'Tested' condition; only maintained if the component implements TestConfiguration, and tests are enabled.
+	ConditionTypeTested ConditionType = "Tested"
 )
 
 // Condition Status. Can be one of 'True', 'False', 'Unknown'.
@@ -313,7 +593,7 @@ const (
 	ConditionUnknown ConditionStatus = "Unknown"
 )
 
-// Component state. Can be one of 'Ready', 'Pending', 'Processing', 'DeletionPending', 'Deleting', 'Error'.
+// Component state. Can be one of 'Ready', 'Pending', 'Processing', 'DeletionPending', 'Deleting', 'Error', 'Stalled'.
 type State string
 
 const (
@@ -329,4 +609,6 @@ const (
 	StateDeleting State = "Deleting"
 	// Component state 'Error'.
 	StateError State = "Error"
+	// Component state 'Stalled'.
+	StateStalled State = "Stalled"
 )
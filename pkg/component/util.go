@@ -18,6 +18,18 @@ func capitalize(s string) string {
 	return strings.ToUpper(s[0:1]) + s[1:]
 }
 
+// return reason if not empty; otherwise return the given default reason (or the timeout reason, if timeout is true)
+func defaultReason(reason string, defaultReason string, timeout bool) string {
+	switch {
+	case reason != "":
+		return reason
+	case timeout:
+		return ReadyConditionReasonTimeout
+	default:
+		return defaultReason
+	}
+}
+
 func addJitter(d *time.Duration, minPercent int, maxPercent int) {
 	if minPercent > maxPercent {
 		return
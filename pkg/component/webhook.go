@@ -0,0 +1,146 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	legacyerrors "github.com/pkg/errors"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+// ValidatorOptions are creation options for a Validator.
+type ValidatorOptions struct {
+	// Whether the rendered objects are additionally validated by a server-side-apply dry-run against the target cluster.
+	// Objects whose type or namespace does not (yet) exist in the target cluster are skipped.
+	DryRun bool
+	// Default service account used for impersonation of clients; should match the according reconciler option.
+	DefaultServiceAccount *string
+	// SchemeBuilder allows to define additional schemes to be made available in the target client;
+	// should match the according reconciler option.
+	SchemeBuilder types.SchemeBuilder
+	// Namespaces (besides the component's own namespace) from which configmap or secret references may be loaded;
+	// should match the according reconciler option.
+	ReferenceNamespaces []string
+	// Duration after which cached clients are dropped if unused.
+	// If unspecified, 15 minutes is assumed.
+	ClientTTL *time.Duration
+	// Whether kubeconfigs supplied by components may use exec credential plugins (which then run at admission time).
+	// If unspecified, false is assumed.
+	EnableExecCredentialPlugins *bool
+}
+
+// Validator provides an implementation of controller-runtime's admission.Validator interface, for a given Component type T.
+// On creation (and on spec changes), it resolves the references of the component and renders its manifests (in the same way
+// as the according Reconciler would), and rejects the request if that fails, such that errors become visible to the user immediately,
+// instead of surfacing later in the component's status.
+// References which cannot be resolved yet (e.g. because the referenced secret does not exist) do not cause a rejection,
+// but are reported as admission warnings.
+type Validator[T Component] struct {
+	reconciler *Reconciler[T]
+	dryRun     bool
+}
+
+var _ admission.Validator[Component] = &Validator[Component]{}
+
+// Create a new Validator.
+// Here, name should be the name of the according reconciler (it is used as field owner in dry-run requests, and to build annotation keys);
+// resourceGenerator must be an implementation of the manifests.Generator interface (usually the one passed to the reconciler).
+func NewValidator[T Component](name string, resourceGenerator manifests.Generator, options ValidatorOptions) *Validator[T] {
+	return &Validator[T]{
+		reconciler: NewReconciler[T](name, resourceGenerator, ReconcilerOptions{
+			DefaultServiceAccount:       options.DefaultServiceAccount,
+			SchemeBuilder:               options.SchemeBuilder,
+			ReferenceNamespaces:         options.ReferenceNamespaces,
+			ClientTTL:                   options.ClientTTL,
+			EnableExecCredentialPlugins: options.EnableExecCredentialPlugins,
+		}),
+		dryRun: options.DryRun,
+	}
+}
+
+// Register the validator as validating webhook with a given controller-runtime Manager.
+// Note that the webhook server of the manager must be configured, and that an according ValidatingWebhookConfiguration
+// (using the path /validate-<group>-<version>-<kind>, with dots in the group replaced by dashes) must exist in the cluster.
+func (v *Validator[T]) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := v.reconciler.setupReferenceNamespaces(); err != nil {
+		return err
+	}
+	if _, err := v.reconciler.setupClients(mgr); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr, newComponent[T]()).WithValidator(v).Complete(); err != nil {
+		return legacyerrors.Wrap(err, "error creating webhook")
+	}
+	return nil
+}
+
+// Implement admission.Validator.
+func (v *Validator[T]) ValidateCreate(ctx context.Context, component T) (admission.Warnings, error) {
+	return v.validate(ctx, component)
+}
+
+// Implement admission.Validator; updates not changing the spec of the component are not validated.
+func (v *Validator[T]) ValidateUpdate(ctx context.Context, oldComponent T, component T) (admission.Warnings, error) {
+	if reflect.DeepEqual(getSpec(oldComponent), getSpec(component)) {
+		return nil, nil
+	}
+	return v.validate(ctx, component)
+}
+
+// Implement admission.Validator; deletions are always allowed.
+func (v *Validator[T]) ValidateDelete(ctx context.Context, component T) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *Validator[T]) validate(ctx context.Context, component T) (admission.Warnings, error) {
+	r := v.reconciler
+
+	if !component.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	getTargetClient := func() (client.Client, error) {
+		return r.getClientForComponent(component)
+	}
+	componentDigest, err := resolveReferences(ctx, r.client, r.hookClient, getTargetClient, component, r.referenceNamespaces, r.name)
+	if err != nil {
+		// note: references which do not exist yet produce retriable errors; these should not block the creation of the component
+		retriableError := &types.RetriableError{}
+		if errors.As(err, retriableError) {
+			return admission.Warnings{fmt.Sprintf("skipping validation because references could not be resolved: %s", err)}, nil
+		}
+		return nil, legacyerrors.Wrap(err, "error resolving references")
+	}
+	ctx = r.newContextForComponent(ctx, component, componentDigest)
+
+	localClient, err := r.getLocalClientForComponent(component)
+	if err != nil {
+		return nil, legacyerrors.Wrap(err, "error getting local client for component")
+	}
+	targetClient, err := r.getClientForComponent(component)
+	if err != nil {
+		return nil, legacyerrors.Wrap(err, "error getting client for component")
+	}
+	target, err := r.getTargetForComponent(ctx, component, localClient, targetClient, true)
+	if err != nil {
+		return nil, legacyerrors.Wrap(err, "error getting targets for component")
+	}
+	if err := target.Validate(ctx, component, componentDigest, component.GetStatus().Revision+1, v.dryRun); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
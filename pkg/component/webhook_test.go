@@ -0,0 +1,70 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/reconciler"
+)
+
+var _ = ginkgo.Describe("testing: webhook.go", func() {
+	var ctx context.Context
+	var component *testComponent
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		component = newTestComponent("default", "test")
+	})
+
+	newTarget := func(resourceGenerator manifests.Generator) *reconcileTarget[*testComponent] {
+		clnt := cluster.NewClient(newTestClient(), nil, record.NewFakeRecorder(100), nil, nil)
+		return newReconcileTarget[*testComponent]("test", "test", clnt, clnt, resourceGenerator, reconciler.ReconcilerOptions{})
+	}
+
+	ginkgo.It("should accept valid manifests without touching the cluster", func() {
+		target := newTarget(&testGenerator{})
+		Expect(target.Validate(ctx, component, "digest", 1, false)).To(Succeed())
+		Expect(target.Validate(ctx, component, "digest", 1, true)).To(Succeed())
+		Expect(target.client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test"}, &corev1.ConfigMap{})).NotTo(Succeed())
+		Expect(component.Status.Inventory).To(BeEmpty())
+	})
+
+	ginkgo.It("should reject the component if rendering fails", func() {
+		target := newTarget(&testStaticGenerator{err: fmt.Errorf("invalid values")})
+		Expect(target.Validate(ctx, component, "digest", 1, false)).To(MatchError(ContainSubstring("invalid values")))
+	})
+
+	ginkgo.It("should reject the component if the rendered objects are invalid", func() {
+		target := newTarget(&testStaticGenerator{objects: []client.Object{
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+		}})
+		Expect(target.Validate(ctx, component, "digest", 1, false)).To(MatchError(ContainSubstring("duplicate object")))
+	})
+
+	ginkgo.It("should not validate updates which do not change the spec, or deletions", func() {
+		validator := &Validator[*testComponent]{}
+		newComponent := component.DeepCopyObject().(*testComponent)
+		newComponent.Annotations = map[string]string{"changed": "true"}
+		Expect(validator.ValidateUpdate(ctx, component, newComponent)).Error().NotTo(HaveOccurred())
+		Expect(validator.ValidateDelete(ctx, component)).Error().NotTo(HaveOccurred())
+		newComponent.DeletionTimestamp = new(metav1.Now())
+		newComponent.Spec.Secret = &SecretReference{Name: "test"}
+		Expect(validator.ValidateUpdate(ctx, component, newComponent)).Error().NotTo(HaveOccurred())
+	})
+})
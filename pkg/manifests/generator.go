@@ -21,6 +21,9 @@ type tranformableGenerator struct {
 	objectTransformers    []ObjectTransformer
 }
 
+var _ OutputGenerator = &tranformableGenerator{}
+var _ CombinedNotesGenerator = &tranformableGenerator{}
+
 // Wrap a given Generator into a TransformableGenerator, to allow to attach further parameter or object transformers to it.
 func NewGenerator(generator Generator) TransformableGenerator {
 	return &tranformableGenerator{generator: generator}
@@ -37,17 +40,77 @@ func (g *tranformableGenerator) WithObjectTransformer(transformer ObjectTransfor
 }
 
 func (g *tranformableGenerator) Generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, error) {
-	for i, transformer := range g.parameterTransformers {
-		_parameters, err := transformer.TransformParameters(namespace, name, parameters)
+	parameters, err := g.transformParameters(namespace, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := g.generator.Generate(ctx, namespace, name, parameters)
+	if err != nil {
+		return nil, err
+	}
+	return g.transformObjects(namespace, name, objects)
+}
+
+func (g *tranformableGenerator) GenerateWithNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, string, error) {
+	parameters, err := g.transformParameters(namespace, name, parameters)
+	if err != nil {
+		return nil, "", err
+	}
+	var objects []client.Object
+	var notes string
+	switch generator := g.generator.(type) {
+	case CombinedNotesGenerator:
+		objects, notes, err = generator.GenerateWithNotes(ctx, namespace, name, parameters)
 		if err != nil {
-			return nil, legacyerrors.Wrapf(err, "error calling parameter transformer (%d)", i)
+			return nil, "", err
+		}
+	case NotesGenerator:
+		objects, err = generator.Generate(ctx, namespace, name, parameters)
+		if err != nil {
+			return nil, "", err
+		}
+		notes, err = generator.GetNotes(ctx, namespace, name, parameters)
+		if err != nil {
+			return nil, "", err
+		}
+	default:
+		objects, err = generator.Generate(ctx, namespace, name, parameters)
+		if err != nil {
+			return nil, "", err
 		}
-		parameters = _parameters
 	}
-	objects, err := g.generator.Generate(ctx, namespace, name, parameters)
+	objects, err = g.transformObjects(namespace, name, objects)
+	if err != nil {
+		return nil, "", err
+	}
+	return objects, notes, nil
+}
+
+func (g *tranformableGenerator) GetOutputs(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]Output, error) {
+	outputGenerator, ok := g.generator.(OutputGenerator)
+	if !ok {
+		return nil, nil
+	}
+	parameters, err := g.transformParameters(namespace, name, parameters)
 	if err != nil {
 		return nil, err
 	}
+	return outputGenerator.GetOutputs(ctx, namespace, name, parameters)
+}
+
+func (g *tranformableGenerator) GetNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) (string, error) {
+	notesGenerator, ok := g.generator.(NotesGenerator)
+	if !ok {
+		return "", nil
+	}
+	parameters, err := g.transformParameters(namespace, name, parameters)
+	if err != nil {
+		return "", err
+	}
+	return notesGenerator.GetNotes(ctx, namespace, name, parameters)
+}
+
+func (g *tranformableGenerator) transformObjects(namespace string, name string, objects []client.Object) ([]client.Object, error) {
 	for i, transformer := range g.objectTransformers {
 		_objects, err := transformer.TransformObjects(namespace, name, objects)
 		if err != nil {
@@ -57,3 +120,14 @@ func (g *tranformableGenerator) Generate(ctx context.Context, namespace string,
 	}
 	return objects, nil
 }
+
+func (g *tranformableGenerator) transformParameters(namespace string, name string, parameters types.Unstructurable) (types.Unstructurable, error) {
+	for i, transformer := range g.parameterTransformers {
+		_parameters, err := transformer.TransformParameters(namespace, name, parameters)
+		if err != nil {
+			return nil, legacyerrors.Wrapf(err, "error calling parameter transformer (%d)", i)
+		}
+		parameters = _parameters
+	}
+	return parameters, nil
+}
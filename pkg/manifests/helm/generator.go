@@ -11,6 +11,7 @@ import (
 	"io/fs"
 	"strconv"
 	"strings"
+	"text/template"
 
 	"github.com/sap/go-generics/slices"
 
@@ -22,18 +23,28 @@ import (
 	"github.com/sap/component-operator-runtime/pkg/types"
 )
 
+// HelmGeneratorOptions allows to tweak the behavior of the helm generator.
+type HelmGeneratorOptions struct {
+	// If defined, the rendered manifests are passed through the given post-renderer; the post-renderer is called before
+	// hook metadata is evaluated, and before object transformers (possibly attached to the generator) are called.
+	PostRenderer PostRenderer
+	// If defined, custom template functions; they must not collide with any existing function (that is, the sprig functions, the functions
+	// provided by this module, such as toYaml, and the functions bound at render time, such as include, tpl, lookup or localLookup);
+	// collisions are reported as error by NewHelmGeneratorWithOptions()
+	FuncMap template.FuncMap
+}
+
 // HelmGenerator is a Generator implementation that basically renders a given Helm chart.
-// A few restrictions apply to the provided Helm chart: it must not contain any subcharts, some template functions are not supported,
-// some bultin variables are not supported, and hooks are processed in a slightly different fashion.
+// A few restrictions apply to the provided Helm chart: some template functions are not supported, and hooks are processed in a slightly different fashion.
+// Builtin objects are populated as with Helm 3; in particular, .Capabilities is discovered from the target cluster of the component.
 // Note: HelmGenerator's Generate() method expects local client, client and reconciler name to be set in the passed context;
 // see: Context.WithLocalClient(), Context.WithClient() and Context.WithReconcilerName() in package pkg/component.
 type HelmGenerator struct {
-	chart *helm.Chart
+	chart        *helm.Chart
+	postRenderer PostRenderer
 }
 
-var _ manifests.Generator = &HelmGenerator{}
-
-// TODO: add a way to pass custom template functions
+var _ manifests.CombinedNotesGenerator = &HelmGenerator{}
 
 // Create a new HelmGenerator.
 // The client parameter is deprecated (ignored) and will be removed in a future release.
@@ -44,12 +55,20 @@ var _ manifests.Generator = &HelmGenerator{}
 
 // An empty chartPath will be treated like ".".
 func NewHelmGenerator(fsys fs.FS, chartPath string, _ client.Client) (*HelmGenerator, error) {
-	chart, err := helm.ParseChart(fsys, chartPath, nil)
+	return NewHelmGeneratorWithOptions(fsys, chartPath, HelmGeneratorOptions{})
+}
+
+// Create a new HelmGenerator with the given options; the parameters fsys and chartPath are interpreted as with NewHelmGenerator().
+func NewHelmGeneratorWithOptions(fsys fs.FS, chartPath string, options HelmGeneratorOptions) (*HelmGenerator, error) {
+	chart, err := helm.ParseChartWithOptions(fsys, chartPath, helm.ChartOptions{FuncMap: options.FuncMap})
 	if err != nil {
 		return nil, err
 	}
 
-	return &HelmGenerator{chart: chart}, nil
+	return &HelmGenerator{
+		chart:        chart,
+		postRenderer: options.PostRenderer,
+	}, nil
 }
 
 // Create a new HelmGenerator as TransformableGenerator.
@@ -61,6 +80,16 @@ func NewTransformableHelmGenerator(fsys fs.FS, chartPath string, _ client.Client
 	return manifests.NewGenerator(g), nil
 }
 
+// Create a new HelmGenerator with the given options as TransformableGenerator; the parameters fsys and chartPath are interpreted as with NewHelmGenerator().
+// This allows to combine options (such as a post-renderer) with parameter or object transformers.
+func NewTransformableHelmGeneratorWithOptions(fsys fs.FS, chartPath string, options HelmGeneratorOptions) (manifests.TransformableGenerator, error) {
+	g, err := NewHelmGeneratorWithOptions(fsys, chartPath, options)
+	if err != nil {
+		return nil, err
+	}
+	return manifests.NewGenerator(g), nil
+}
+
 // Create a new HelmGenerator with a ParameterTransformer attached (further transformers can be attached to the returned generator object).
 func NewHelmGeneratorWithParameterTransformer(fsys fs.FS, chartPath string, _ client.Client, transformer manifests.ParameterTransformer) (manifests.TransformableGenerator, error) {
 	g, err := NewTransformableHelmGenerator(fsys, chartPath, nil)
@@ -81,43 +110,44 @@ func NewHelmGeneratorWithObjectTransformer(fsys fs.FS, chartPath string, _ clien
 
 // Generate resource descriptors.
 func (g *HelmGenerator) Generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, error) {
+	objects, _, err := g.generate(ctx, namespace, name, parameters, false)
+	return objects, err
+}
+
+// Generate resource descriptors, and render the usage notes (as GetNotes() does) in the same pass.
+func (g *HelmGenerator) GenerateWithNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, string, error) {
+	return g.generate(ctx, namespace, name, parameters, true)
+}
+
+func (g *HelmGenerator) generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable, withNotes bool) ([]client.Object, string, error) {
 	var objects []client.Object
 
 	reconcilerName, err := component.ReconcilerNameFromContext(ctx)
 	if err != nil {
-		return nil, err
-	}
-	localClient, err := component.LocalClientFromContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-	clnt, err := component.ClientFromContext(ctx)
-	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	componentRevision, err := component.ComponentRevisionFromContext(ctx)
+	renderContext, err := newRenderContext(ctx, namespace, name, parameters)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	isInstall := componentRevision == 1
+	isInstall := renderContext.Release.IsInstall
 
-	renderedObjects, err := g.chart.Render(helm.RenderContext{
-		LocalClient:     localClient,
-		Client:          clnt,
-		DiscoveryClient: clnt.DiscoveryClient(),
-		Release: &helm.Release{
-			Namespace: namespace,
-			Name:      name,
-			Service:   reconcilerName,
-			IsInstall: isInstall,
-			IsUpgrade: !isInstall,
-			Revision:  componentRevision,
-		},
-		Values: parameters.ToUnstructured(),
-	})
+	var renderedObjects []client.Object
+	var notes string
+	if withNotes {
+		renderedObjects, notes, err = g.chart.RenderWithNotes(renderContext)
+	} else {
+		renderedObjects, err = g.chart.Render(renderContext)
+	}
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if g.postRenderer != nil {
+		renderedObjects, err = postRender(ctx, g.postRenderer, renderedObjects)
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
 	annotationKeyReconcilePolicy := reconcilerName + "/" + types.AnnotationKeySuffixReconcilePolicy
@@ -125,18 +155,21 @@ func (g *HelmGenerator) Generate(ctx context.Context, namespace string, name str
 	annotationKeyDeletePolicy := reconcilerName + "/" + types.AnnotationKeySuffixDeletePolicy
 	annotationKeyApplyOrder := reconcilerName + "/" + types.AnnotationKeySuffixApplyOrder
 	annotationKeyPurgeOrder := reconcilerName + "/" + types.AnnotationKeySuffixPurgeOrder
+	annotationKeyDeleteHook := reconcilerName + "/" + types.AnnotationKeySuffixDeleteHook
+	annotationKeyDeleteHookCleanupPolicy := reconcilerName + "/" + types.AnnotationKeySuffixDeleteHookCleanupPolicy
+	annotationKeyTestHook := reconcilerName + "/" + types.AnnotationKeySuffixTestHook
 
 	for _, object := range renderedObjects {
 		annotations := object.GetAnnotations()
 		for key := range annotations {
 			if strings.HasPrefix(key, reconcilerName+"/") {
-				return nil, fmt.Errorf("annotation %s must not be set (object: %s)", key, types.ObjectKeyToString(object))
+				return nil, "", fmt.Errorf("annotation %s must not be set (object: %s)", key, types.ObjectKeyToString(object))
 			}
 		}
 
 		resourceMetadata, err := helm.ParseResourceMetadata(object)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		if resourceMetadata != nil {
 			switch resourceMetadata.Policy {
@@ -151,24 +184,63 @@ func (g *HelmGenerator) Generate(ctx context.Context, namespace string, name str
 
 		hookMetadata, err := helm.ParseHookMetadata(object)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		if hookMetadata != nil {
-			if slices.Contains(hookMetadata.Types, helm.HookTypePreDelete) {
-				return nil, fmt.Errorf("helm hook type %s not supported (object: %s)", helm.HookTypePreDelete, types.ObjectKeyToString(object))
-			}
-			if slices.Contains(hookMetadata.Types, helm.HookTypePostDelete) {
-				return nil, fmt.Errorf("helm hook type %s not supported (object: %s)", helm.HookTypePostDelete, types.ObjectKeyToString(object))
-			}
 			hookMetadata.Types = slices.Remove(hookMetadata.Types, helm.HookTypePreRollback)
 			hookMetadata.Types = slices.Remove(hookMetadata.Types, helm.HookTypePostRollback)
+			if len(hookMetadata.Types) == 0 {
+				continue
+			}
+			if slices.All(hookMetadata.Types, func(t string) bool { return t == helm.HookTypeTest || t == helm.HookTypeTestSuccess }) {
+				// test hooks are passed to the reconciler, which runs them (if tests are enabled for the component) after the component became ready;
+				// note: hook deletion policies are not evaluated for test hooks; test objects are recreated whenever the tests are re-run
+				annotations[annotationKeyTestHook] = "true"
+				annotations[annotationKeyApplyOrder] = strconv.Itoa(hookMetadata.Weight)
+				object.SetAnnotations(annotations)
+				objects = append(objects, object)
+				continue
+			}
+			// note: test hook types are ignored if combined with other hook types
 			hookMetadata.Types = slices.Remove(hookMetadata.Types, helm.HookTypeTest)
 			hookMetadata.Types = slices.Remove(hookMetadata.Types, helm.HookTypeTestSuccess)
-			if len(hookMetadata.Types) == 0 {
+			isPreDelete := slices.Contains(hookMetadata.Types, helm.HookTypePreDelete)
+			isPostDelete := slices.Contains(hookMetadata.Types, helm.HookTypePostDelete)
+			if isPreDelete || isPostDelete {
+				// delete hooks are passed to the reconciler, which creates them when the component is deleted;
+				// since the same object cannot be a delete hook and a regular dependent object at the same time,
+				// delete hooks must not be combined with other hook types
+				if isPreDelete && isPostDelete {
+					return nil, "", fmt.Errorf("helm hook types %s and %s cannot be combined (object: %s)", helm.HookTypePreDelete, helm.HookTypePostDelete, types.ObjectKeyToString(object))
+				}
+				if len(hookMetadata.Types) > 1 {
+					return nil, "", fmt.Errorf("helm hook types %s and %s cannot be combined with other hook types (object: %s)", helm.HookTypePreDelete, helm.HookTypePostDelete, types.ObjectKeyToString(object))
+				}
+				if isPreDelete {
+					annotations[annotationKeyDeleteHook] = types.DeleteHookPreDelete
+				} else {
+					annotations[annotationKeyDeleteHook] = types.DeleteHookPostDelete
+				}
+				annotations[annotationKeyApplyOrder] = strconv.Itoa(hookMetadata.Weight)
+				// note: as in Helm, before-hook-creation is assumed if no delete policy is specified; this ensures that failed
+				// hook objects are removed (and the hook is retried), instead of blocking the deletion of the component forever
+				if len(hookMetadata.DeletePolicies) == 0 || slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyBeforeHookCreation) {
+					annotations[annotationKeyUpdatePolicy] = types.UpdatePolicyRecreate
+				}
+				switch {
+				case slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyHookSucceeded) && slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyHookFailed):
+					annotations[annotationKeyDeleteHookCleanupPolicy] = types.DeleteHookCleanupPolicyAlways
+				case slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyHookSucceeded):
+					annotations[annotationKeyDeleteHookCleanupPolicy] = types.DeleteHookCleanupPolicyOnSuccess
+				case slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyHookFailed):
+					annotations[annotationKeyDeleteHookCleanupPolicy] = types.DeleteHookCleanupPolicyOnFailure
+				}
+				object.SetAnnotations(annotations)
+				objects = append(objects, object)
 				continue
 			}
 			if slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyHookFailed) {
-				return nil, fmt.Errorf("helm delete policy %s is not supported (object: %s)", helm.HookDeletePolicyHookFailed, types.ObjectKeyToString(object))
+				return nil, "", fmt.Errorf("helm delete policy %s is not supported (object: %s)", helm.HookDeletePolicyHookFailed, types.ObjectKeyToString(object))
 			}
 			if slices.Contains(hookMetadata.DeletePolicies, helm.HookDeletePolicyBeforeHookCreation) {
 				annotations[annotationKeyUpdatePolicy] = types.UpdatePolicyRecreate
@@ -227,5 +299,51 @@ func (g *HelmGenerator) Generate(ctx context.Context, namespace string, name str
 		objects = append(objects, object)
 	}
 
-	return objects, nil
+	return objects, notes, nil
+}
+
+// Get usage notes, as rendered from the chart's templates/NOTES.txt (if existing).
+// The same context requirements apply as for Generate().
+func (g *HelmGenerator) GetNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) (string, error) {
+	renderContext, err := newRenderContext(ctx, namespace, name, parameters)
+	if err != nil {
+		return "", err
+	}
+	return g.chart.RenderNotes(renderContext)
+}
+
+func newRenderContext(ctx context.Context, namespace string, name string, parameters types.Unstructurable) (helm.RenderContext, error) {
+	reconcilerName, err := component.ReconcilerNameFromContext(ctx)
+	if err != nil {
+		return helm.RenderContext{}, err
+	}
+	localClient, err := component.LocalClientFromContext(ctx)
+	if err != nil {
+		return helm.RenderContext{}, err
+	}
+	clnt, err := component.ClientFromContext(ctx)
+	if err != nil {
+		return helm.RenderContext{}, err
+	}
+	componentRevision, err := component.ComponentRevisionFromContext(ctx)
+	if err != nil {
+		return helm.RenderContext{}, err
+	}
+
+	isInstall := componentRevision == 1
+
+	return helm.RenderContext{
+		LocalClient:     localClient,
+		Client:          clnt,
+		DiscoveryClient: clnt.DiscoveryClient(),
+		Release: &helm.Release{
+			Namespace: namespace,
+			Name:      name,
+			Service:   reconcilerName,
+			IsInstall: isInstall,
+			IsUpgrade: !isInstall,
+			Revision:  componentRevision,
+		},
+		Values: parameters.ToUnstructured(),
+	}, nil
 }
@@ -0,0 +1,129 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm_test
+
+import (
+	"context"
+	"testing/fstest"
+	"text/template"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/component"
+	"github.com/sap/component-operator-runtime/pkg/manifests/helm"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = Describe("testing: generator.go", func() {
+	var ctx context.Context
+	var fsys fstest.MapFS
+
+	BeforeEach(func() {
+		clnt := cluster.NewClient(nil, fake.NewSimpleClientset().Discovery(), nil, nil, nil)
+		ctx = component.NewContext(context.Background()).
+			WithReconcilerName("test.example.io").
+			WithLocalClient(clnt).
+			WithClient(clnt).
+			WithComponentRevision(1)
+		fsys = fstest.MapFS{
+			"mychart/Chart.yaml":            {Data: []byte("apiVersion: v2\nname: mychart\nversion: 0.1.0\n")},
+			"mychart/charts/sub/Chart.yaml": {Data: []byte("apiVersion: v2\nname: sub\nversion: 0.1.0\n")},
+			"mychart/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name | companyName }}
+data:
+  upper: {{ upper "hello" }}
+  yaml: {{ toYaml .Values.data | quote }}
+`)},
+			"mychart/charts/sub/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name | companyName }}-sub
+`)},
+		}
+	})
+
+	generate := func(funcs template.FuncMap) ([]client.Object, error) {
+		generator, err := helm.NewHelmGeneratorWithOptions(fsys, "mychart", helm.HelmGeneratorOptions{FuncMap: funcs})
+		if err != nil {
+			return nil, err
+		}
+		return generator.Generate(ctx, "my-namespace", "my-name", types.UnstructurableMap(map[string]any{"data": "x"}))
+	}
+
+	It("should make custom template functions available to the chart and its subcharts", func() {
+		objects, err := generate(template.FuncMap{
+			"companyName": func(s string) string { return "acme-" + s },
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(2))
+		names := []string{objects[0].GetName(), objects[1].GetName()}
+		Expect(names).To(ConsistOf("acme-my-name", "acme-my-name-sub"))
+	})
+
+	It("should reject custom template functions colliding with standard functions", func() {
+		_, err := generate(template.FuncMap{
+			"companyName": func(s string) string { return s },
+			"upper":       func(s string) string { return "custom-" + s },
+		})
+		Expect(err).To(MatchError(ContainSubstring("custom template function upper collides with existing function")))
+		_, err = generate(template.FuncMap{
+			"companyName": func(s string) string { return s },
+			"toYaml":      func(v any) (string, error) { return "custom-yaml", nil },
+		})
+		Expect(err).To(MatchError(ContainSubstring("custom template function toYaml collides with existing function")))
+	})
+
+	It("should reject custom template functions colliding with functions bound at render time", func() {
+		_, err := generate(template.FuncMap{
+			"companyName": func(s string) string { return s },
+			"include":     func(name string, data any) (string, error) { return "", nil },
+		})
+		Expect(err).To(MatchError(ContainSubstring("custom template function include collides with existing function")))
+	})
+
+	It("should reject invalid custom template functions", func() {
+		_, err := generate(template.FuncMap{
+			"companyName": "not a function",
+		})
+		Expect(err).To(MatchError(ContainSubstring("value is not a function")))
+	})
+
+	It("should assume delete policy before-hook-creation for delete hooks without delete policy", func() {
+		fsys["mychart/templates/hooks.yaml"] = &fstest.MapFile{Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: default-policy
+  annotations:
+    helm.sh/hook: pre-delete
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: explicit-policy
+  annotations:
+    helm.sh/hook: post-delete
+    helm.sh/hook-delete-policy: hook-succeeded
+`)}
+		objects, err := generate(template.FuncMap{
+			"companyName": func(s string) string { return s },
+		})
+		Expect(err).NotTo(HaveOccurred())
+		hooks := make(map[string]client.Object)
+		for _, object := range objects {
+			hooks[object.GetName()] = object
+		}
+		Expect(hooks["default-policy"].GetAnnotations()).To(HaveKeyWithValue("test.example.io/update-policy", types.UpdatePolicyRecreate))
+		Expect(hooks["explicit-policy"].GetAnnotations()).NotTo(HaveKey("test.example.io/update-policy"))
+		Expect(hooks["explicit-policy"].GetAnnotations()).To(HaveKeyWithValue("test.example.io/delete-hook-cleanup-policy", types.DeleteHookCleanupPolicyOnSuccess))
+	})
+})
@@ -0,0 +1,151 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kyaml "sigs.k8s.io/yaml"
+)
+
+// PostRenderer allows to manipulate the manifests rendered by a HelmGenerator.
+// The rendered manifests are passed as multi-document YAML stream, and the modified manifests must be returned in the same format.
+// Note that this interface is identical to the one of Helm's post-renderers (helm.sh/helm/v3/pkg/postrender), such that existing
+// implementations can be used as they are.
+type PostRenderer interface {
+	Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error)
+}
+
+// ContextPostRenderer may be implemented by post-renderers which support cancellation; if a post-renderer implements this interface,
+// RunWithContext() is called (with the context passed to the generator) instead of Run().
+type ContextPostRenderer interface {
+	PostRenderer
+	RunWithContext(ctx context.Context, renderedManifests *bytes.Buffer) (*bytes.Buffer, error)
+}
+
+// PostRendererFunc is a function implementing the PostRenderer interface.
+type PostRendererFunc func(renderedManifests *bytes.Buffer) (*bytes.Buffer, error)
+
+var _ PostRenderer = PostRendererFunc(nil)
+
+func (f PostRendererFunc) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	return f(renderedManifests)
+}
+
+const defaultExecPostRendererTimeout = 1 * time.Minute
+
+type execPostRenderer struct {
+	binaryPath string
+	args       []string
+	timeout    time.Duration
+}
+
+var _ ContextPostRenderer = &execPostRenderer{}
+
+// Create a PostRenderer which runs the specified binary (with the given arguments), passing the rendered manifests on stdin,
+// and reading the modified manifests from stdout, as it is done by Helm's --post-renderer flag.
+// If binaryPath contains no path separator, the binary is looked up in the directories named by the PATH environment variable.
+// The binary is killed if it does not complete within one minute (or if the context passed to the generator is cancelled).
+func NewExecPostRenderer(binaryPath string, args ...string) (PostRenderer, error) {
+	return NewExecPostRendererWithTimeout(defaultExecPostRendererTimeout, binaryPath, args...)
+}
+
+// Same as NewExecPostRenderer(), but with the given timeout (instead of one minute).
+func NewExecPostRendererWithTimeout(timeout time.Duration, binaryPath string, args ...string) (PostRenderer, error) {
+	if timeout <= 0 {
+		return nil, fmt.Errorf("invalid timeout for post-renderer %s: %s", binaryPath, timeout)
+	}
+	fullPath, err := exec.LookPath(binaryPath)
+	if err != nil {
+		return nil, fmt.Errorf("error finding post-renderer %s: %w", binaryPath, err)
+	}
+	return &execPostRenderer{
+		binaryPath: fullPath,
+		args:       args,
+		timeout:    timeout,
+	}, nil
+}
+
+func (r *execPostRenderer) Run(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	return r.RunWithContext(context.Background(), renderedManifests)
+}
+
+func (r *execPostRenderer) RunWithContext(ctx context.Context, renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, r.binaryPath, r.args...)
+	// note: do not wait forever for the output streams to be closed (e.g. if the binary spawned children which outlive it)
+	cmd.WaitDelay = 5 * time.Second
+	cmd.Stdin = renderedManifests
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = fmt.Errorf("%w (%w)", err, ctxErr)
+		}
+		return nil, fmt.Errorf("error running post-renderer %s: %w (stderr: %s)", r.binaryPath, err, strings.TrimSpace(stderr.String()))
+	}
+	// note: as helm does it, empty output is considered an error (because it usually indicates a misbehaving post-renderer)
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil, fmt.Errorf("post-renderer %s returned empty output", r.binaryPath)
+	}
+	return &stdout, nil
+}
+
+// Serialize the given objects into a multi-document YAML stream, pass it through the post-renderer, and parse the result.
+func postRender(ctx context.Context, postRenderer PostRenderer, objects []client.Object) ([]client.Object, error) {
+	var buf bytes.Buffer
+	for _, object := range objects {
+		raw, err := kyaml.Marshal(object)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(raw)
+	}
+
+	var modifiedBuf *bytes.Buffer
+	var err error
+	if contextPostRenderer, ok := postRenderer.(ContextPostRenderer); ok {
+		modifiedBuf, err = contextPostRenderer.RunWithContext(ctx, &buf)
+	} else {
+		modifiedBuf, err = postRenderer.Run(&buf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error running post-renderer: %w", err)
+	}
+	if modifiedBuf == nil {
+		return nil, fmt.Errorf("error running post-renderer: no output returned")
+	}
+
+	var modifiedObjects []client.Object
+	decoder := utilyaml.NewYAMLToJSONDecoder(modifiedBuf)
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error parsing output of post-renderer: %w", err)
+		}
+		if object.Object == nil {
+			continue
+		}
+		modifiedObjects = append(modifiedObjects, object)
+	}
+	return modifiedObjects, nil
+}
@@ -0,0 +1,134 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm_test
+
+import (
+	"bytes"
+	"context"
+	"testing/fstest"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/cluster"
+	"github.com/sap/component-operator-runtime/pkg/component"
+	"github.com/sap/component-operator-runtime/pkg/manifests/helm"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = Describe("testing: postrender.go", func() {
+	var ctx context.Context
+	var fsys fstest.MapFS
+
+	BeforeEach(func() {
+		clnt := cluster.NewClient(nil, fake.NewSimpleClientset().Discovery(), nil, nil, nil)
+		ctx = component.NewContext(context.Background()).
+			WithReconcilerName("test.example.io").
+			WithLocalClient(clnt).
+			WithClient(clnt).
+			WithComponentRevision(1)
+		fsys = fstest.MapFS{
+			"mychart/Chart.yaml": {Data: []byte("apiVersion: v2\nname: mychart\nversion: 0.1.0\n")},
+			"mychart/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+data:
+  message: hello
+`)},
+		}
+	})
+
+	generate := func(postRenderer helm.PostRenderer) ([]client.Object, error) {
+		generator, err := helm.NewHelmGeneratorWithOptions(fsys, "mychart", helm.HelmGeneratorOptions{PostRenderer: postRenderer})
+		Expect(err).NotTo(HaveOccurred())
+		return generator.Generate(ctx, "my-namespace", "my-name", types.UnstructurableMap(nil))
+	}
+
+	It("should pass the rendered manifests through a post-renderer function, before evaluating hook metadata", func() {
+		var input string
+		objects, err := generate(helm.PostRendererFunc(func(renderedManifests *bytes.Buffer) (*bytes.Buffer, error) {
+			input = renderedManifests.String()
+			return bytes.NewBufferString(input + "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cleanup\n  annotations:\n    helm.sh/hook: pre-delete\n"), nil
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(input).To(ContainSubstring("name: my-name"))
+		Expect(objects).To(HaveLen(2))
+		Expect(objects[0].GetName()).To(Equal("my-name"))
+		Expect(objects[1].GetName()).To(Equal("cleanup"))
+		Expect(objects[1].GetAnnotations()).To(HaveKeyWithValue("test.example.io/delete-hook", types.DeleteHookPreDelete))
+	})
+
+	It("should pass the rendered manifests through an external post-renderer", func() {
+		postRenderer, err := helm.NewExecPostRenderer("sed", "s/message: hello/message: world/")
+		Expect(err).NotTo(HaveOccurred())
+		objects, err := generate(postRenderer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects).To(HaveLen(1))
+		Expect(objects[0].(*unstructured.Unstructured).Object["data"]).To(Equal(map[string]any{"message": "world"}))
+	})
+
+	It("should fail if the external post-renderer fails", func() {
+		postRenderer, err := helm.NewExecPostRenderer("false")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = generate(postRenderer)
+		Expect(err).To(MatchError(ContainSubstring("error running post-renderer")))
+	})
+
+	It("should kill the external post-renderer if it exceeds its timeout", func() {
+		postRenderer, err := helm.NewExecPostRendererWithTimeout(100*time.Millisecond, "sleep", "10")
+		Expect(err).NotTo(HaveOccurred())
+		start := time.Now()
+		_, err = generate(postRenderer)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should kill the external post-renderer if the context is cancelled", func() {
+		postRenderer, err := helm.NewExecPostRenderer("sleep", "10")
+		Expect(err).NotTo(HaveOccurred())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = generate(postRenderer)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+
+	It("should combine post-renderers with object transformers", func() {
+		postRenderer, err := helm.NewExecPostRenderer("sed", "s/message: hello/message: world/")
+		Expect(err).NotTo(HaveOccurred())
+		generator, err := helm.NewTransformableHelmGeneratorWithOptions(fsys, "mychart", helm.HelmGeneratorOptions{PostRenderer: postRenderer})
+		Expect(err).NotTo(HaveOccurred())
+		transformer := &testObjectTransformer{}
+		objects, err := generator.WithObjectTransformer(transformer).Generate(ctx, "my-namespace", "my-name", types.UnstructurableMap(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transformer.data).To(Equal(map[string]any{"message": "world"}))
+		Expect(objects).To(HaveLen(1))
+		Expect(objects[0].GetLabels()).To(HaveKeyWithValue("transformed", "true"))
+	})
+
+	It("should fail if the external post-renderer does not exist", func() {
+		_, err := helm.NewExecPostRenderer("this-post-renderer-does-not-exist")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+type testObjectTransformer struct {
+	data any
+}
+
+func (t *testObjectTransformer) TransformObjects(namespace string, name string, objects []client.Object) ([]client.Object, error) {
+	t.data = objects[0].(*unstructured.Unstructured).Object["data"]
+	objects[0].SetLabels(map[string]string{"transformed": "true"})
+	return objects, nil
+}
@@ -0,0 +1,217 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/sap/component-operator-runtime/internal/helm"
+)
+
+// ChartSource identifies a chart in an OCI registry, or in a (classic) chart repository serving an index.yaml.
+type ChartSource struct {
+	// URL of the chart if it resides in an OCI registry (such as oci://registry.example.io/charts/mychart),
+	// or URL of the chart repository (such as https://charts.example.io).
+	URL string
+	// Name of the chart (only relevant for chart repositories).
+	Name string
+	// Exact version or version constraint (such as ~1.2.0); if empty, the latest stable version is used.
+	Version string
+	// Digest (sha256:<hex>) pinning the chart; for OCI registries, this is the digest of the manifest (and the version is ignored),
+	// for chart repositories, this is the digest of the chart archive. If specified, and the chart is already cached,
+	// it is used without contacting the registry or repository.
+	Digest string
+}
+
+// Credential for accessing an OCI registry or chart repository.
+type Credential struct {
+	Username string
+	Password string
+}
+
+// Credentials for accessing OCI registries or chart repositories, keyed by host (including port, if any);
+// the entry with the empty key (if present) is used for all hosts which have no dedicated entry.
+type Credentials map[string]Credential
+
+// ChartSourceOptions configure how charts are retrieved from remote sources.
+type ChartSourceOptions struct {
+	// Directory in which retrieved charts are cached.
+	// If unspecified, a subdirectory of the user's cache directory (or, if that is not defined, of the temporary directory) is used.
+	CacheDir string
+	// Credentials used to access registries and repositories.
+	Credentials Credentials
+	// HTTP client used to access registries and repositories.
+	// If unspecified, http.DefaultClient is used.
+	HTTPClient *http.Client
+	// Whether OCI registries are accessed through plain HTTP (instead of HTTPS).
+	PlainHTTP bool
+}
+
+// Parse a chart source string; supported formats are oci://<host>/<repository>[:<version>][@<digest>] for charts in OCI registries,
+// and <repository-url>#<name>[:<version>][@<digest>] (where repository-url is an http or https url) for charts in chart repositories.
+func ParseChartSource(s string) (*ChartSource, error) {
+	source := &ChartSource{}
+	if rest, digest, ok := strings.Cut(s, "@sha256:"); ok {
+		s = rest
+		source.Digest = "sha256:" + digest
+	}
+	switch {
+	case strings.HasPrefix(s, "oci://"):
+		if i := strings.LastIndex(s, ":"); i > len("oci://") && !strings.Contains(s[i:], "/") {
+			source.Version = s[i+1:]
+			s = s[:i]
+		}
+		source.URL = s
+	case strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://"):
+		repositoryUrl, name, ok := strings.Cut(s, "#")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid chart source %s: chart name missing (use <repository-url>#<name>[:<version>])", s)
+		}
+		source.URL = repositoryUrl
+		source.Name, source.Version, _ = strings.Cut(name, ":")
+	default:
+		return nil, fmt.Errorf("invalid chart source %s: must be an oci, http or https url", s)
+	}
+	if _, err := url.Parse(source.URL); err != nil {
+		return nil, fmt.Errorf("invalid chart source %s: %w", s, err)
+	}
+	return source, nil
+}
+
+// Retrieve the chart identified by the given source (unless it is already cached), and return a filesystem and path
+// which can be passed to NewHelmGenerator().
+func FetchChart(ctx context.Context, source *ChartSource, options ChartSourceOptions) (fs.FS, string, error) {
+	if options.CacheDir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			cacheDir = os.TempDir()
+		}
+		options.CacheDir = filepath.Join(cacheDir, "component-operator-runtime", "charts")
+	}
+	chartPath, err := helm.PullChart(ctx, helm.ChartReference{
+		URL:     source.URL,
+		Name:    source.Name,
+		Version: source.Version,
+		Digest:  source.Digest,
+	}, helm.PullOptions{
+		CacheDir:    options.CacheDir,
+		Credentials: options.Credentials.lookup,
+		HTTPClient:  options.HTTPClient,
+		PlainHTTP:   options.PlainHTTP,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return os.DirFS(filepath.Dir(chartPath)), filepath.Base(chartPath), nil
+}
+
+// Create a new HelmGenerator for a chart retrieved from the given source.
+func NewHelmGeneratorForSource(ctx context.Context, source *ChartSource, options ChartSourceOptions) (*HelmGenerator, error) {
+	fsys, chartPath, err := FetchChart(ctx, source, options)
+	if err != nil {
+		return nil, err
+	}
+	return NewHelmGenerator(fsys, chartPath, nil)
+}
+
+// Read credentials from a secret; secrets of type kubernetes.io/dockerconfigjson (or kubernetes.io/dockercfg) provide
+// credentials per host; other secrets must contain the keys 'username' and 'password', which are then used for all hosts.
+func CredentialsFromSecret(secret *corev1.Secret) (Credentials, error) {
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson:
+		return ParseDockerConfig(secret.Data[corev1.DockerConfigJsonKey])
+	case corev1.SecretTypeDockercfg:
+		return parseDockerAuths(secret.Data[corev1.DockerConfigKey])
+	default:
+		username, ok := secret.Data["username"]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s does not contain key 'username'", secret.Namespace, secret.Name)
+		}
+		password, ok := secret.Data["password"]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s does not contain key 'password'", secret.Namespace, secret.Name)
+		}
+		return Credentials{"": Credential{Username: string(username), Password: string(password)}}, nil
+	}
+}
+
+// Read credentials from the referenced secret (see CredentialsFromSecret()).
+func LoadCredentials(ctx context.Context, clnt client.Reader, key client.ObjectKey) (Credentials, error) {
+	secret := &corev1.Secret{}
+	if err := clnt.Get(ctx, key, secret); err != nil {
+		return nil, err
+	}
+	return CredentialsFromSecret(secret)
+}
+
+// Parse credentials from a docker config file (as used by docker, or by helm registry login).
+func ParseDockerConfig(raw []byte) (Credentials, error) {
+	config := struct {
+		Auths json.RawMessage `json:"auths"`
+	}{}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, err
+	}
+	if config.Auths == nil {
+		return Credentials{}, nil
+	}
+	return parseDockerAuths(config.Auths)
+}
+
+func parseDockerAuths(raw []byte) (Credentials, error) {
+	auths := make(map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	})
+	if err := json.Unmarshal(raw, &auths); err != nil {
+		return nil, err
+	}
+	credentials := make(Credentials)
+	for server, auth := range auths {
+		credential := Credential{Username: auth.Username, Password: auth.Password}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for %s: %w", server, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth for %s: must have the format <username>:<password>", server)
+			}
+			credential = Credential{Username: username, Password: password}
+		}
+		// note: docker config keys may be urls (such as https://index.docker.io/v1/) or plain hosts
+		host := server
+		if u, err := url.Parse(server); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		credentials[host] = credential
+	}
+	return credentials, nil
+}
+
+func (c Credentials) lookup(host string) (string, string, bool) {
+	if credential, ok := c[host]; ok {
+		return credential.Username, credential.Password, true
+	}
+	if credential, ok := c[""]; ok {
+		return credential.Username, credential.Password, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,285 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests/helm"
+)
+
+var _ = Describe("testing: source.go", func() {
+	var ctx context.Context
+	var archives map[string][]byte
+	var requests []string
+	var server *httptest.Server
+	var options helm.ChartSourceOptions
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		archives = map[string][]byte{
+			"1.0.0": newTestChartArchive("mychart", "1.0.0"),
+			"1.1.0": newTestChartArchive("mychart", "1.1.0"),
+			"2.0.0": newTestChartArchive("mychart", "2.0.0"),
+		}
+		requests = nil
+
+		mux := http.NewServeMux()
+		// chart repository (with basic authentication)
+		mux.HandleFunc("/repo/", func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			switch {
+			case r.URL.Path == "/repo/index.yaml":
+				var b strings.Builder
+				b.WriteString("apiVersion: v1\nentries:\n  mychart:\n")
+				for version, archive := range archives {
+					fmt.Fprintf(&b, "  - version: %s\n    digest: %s\n    urls:\n    - charts/mychart-%s.tgz\n", version, digest(archive)[len("sha256:"):], version)
+				}
+				w.Write([]byte(b.String()))
+			case strings.HasPrefix(r.URL.Path, "/repo/charts/mychart-"):
+				version := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repo/charts/mychart-"), ".tgz")
+				archive, ok := archives[version]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Write(archive)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		// token endpoint of the registry
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			Expect(r.URL.Query().Get("scope")).To(Equal("repository:charts/mychart:pull"))
+			json.NewEncoder(w).Encode(map[string]string{"token": "token"})
+		})
+		// oci registry (with token authentication)
+		mux.HandleFunc("/v2/charts/mychart/", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:charts/mychart:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			manifests := make(map[string][]byte)
+			for version, archive := range archives {
+				manifest, err := json.Marshal(map[string]any{
+					"schemaVersion": 2,
+					"config":        map[string]any{"mediaType": "application/vnd.cncf.helm.config.v1+json", "digest": digest([]byte("{}")), "size": 2},
+					"layers":        []any{map[string]any{"mediaType": "application/vnd.cncf.helm.chart.content.v1.tar+gzip", "digest": digest(archive), "size": len(archive)}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				manifests[version] = manifest
+				manifests[digest(manifest)] = manifest
+			}
+			reference := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			switch {
+			case r.URL.Path == "/v2/charts/mychart/tags/list" && r.URL.Query().Get("last") == "":
+				// note: tags are served in two pages, as registries do it if the number of tags exceeds their page size
+				w.Header().Set("Link", `</v2/charts/mychart/tags/list?n=2&last=1.1.0>; rel="next"`)
+				json.NewEncoder(w).Encode(map[string]any{"name": "charts/mychart", "tags": []string{"1.0.0", "1.1.0"}})
+			case r.URL.Path == "/v2/charts/mychart/tags/list" && r.URL.Query().Get("last") == "1.1.0":
+				json.NewEncoder(w).Encode(map[string]any{"name": "charts/mychart", "tags": []string{"2.0.0", "latest"}})
+			case strings.HasPrefix(r.URL.Path, "/v2/charts/mychart/manifests/") && manifests[reference] != nil:
+				w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+				w.Write(manifests[reference])
+			case strings.HasPrefix(r.URL.Path, "/v2/charts/mychart/blobs/"):
+				for _, archive := range archives {
+					if digest(archive) == reference {
+						w.Write(archive)
+						return
+					}
+				}
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		server = httptest.NewTLSServer(mux)
+
+		options = helm.ChartSourceOptions{
+			CacheDir:    GinkgoT().TempDir(),
+			Credentials: helm.Credentials{strings.TrimPrefix(server.URL, "https://"): helm.Credential{Username: "user", Password: "secret"}},
+			HTTPClient:  server.Client(),
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	readVersion := func(fsys fs.FS, chartPath string) string {
+		raw, err := fs.ReadFile(fsys, chartPath)
+		Expect(err).NotTo(HaveOccurred())
+		for version, archive := range archives {
+			if bytes.Equal(raw, archive) {
+				return version
+			}
+		}
+		return ""
+	}
+
+	It("should fetch charts from oci registries", func() {
+		source, err := helm.ParseChartSource(strings.Replace(server.URL, "https://", "oci://", 1) + "/charts/mychart:1.1.0")
+		Expect(err).NotTo(HaveOccurred())
+		fsys, chartPath, err := helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("1.1.0"))
+
+		source.Version = "^1.0.0"
+		fsys, chartPath, err = helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("1.1.0"))
+		Expect(requests).To(ContainElement("/v2/charts/mychart/tags/list"))
+
+		// the latest version is only listed on the second page of tags
+		source.Version = ""
+		fsys, chartPath, err = helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("2.0.0"))
+	})
+
+	It("should fetch charts from oci registries by digest, and serve them from the cache", func() {
+		source, err := helm.ParseChartSource(strings.Replace(server.URL, "https://", "oci://", 1) + "/charts/mychart:2.0.0")
+		Expect(err).NotTo(HaveOccurred())
+		_, _, err = helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(ContainElement("/v2/charts/mychart/manifests/2.0.0"))
+
+		// determine the manifest digest, so that the chart can be requested by digest
+		response, err := func() (*http.Response, error) {
+			request, err := http.NewRequest(http.MethodGet, server.URL+"/v2/charts/mychart/manifests/2.0.0", nil)
+			Expect(err).NotTo(HaveOccurred())
+			request.Header.Set("Authorization", "Bearer token")
+			return server.Client().Do(request)
+		}()
+		Expect(err).NotTo(HaveOccurred())
+		var buf bytes.Buffer
+		buf.ReadFrom(response.Body)
+		response.Body.Close()
+
+		source.Version = ""
+		source.Digest = digest(buf.Bytes())
+		fsys, chartPath, err := helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("2.0.0"))
+
+		requests = nil
+		fsys, chartPath, err = helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("2.0.0"))
+		Expect(requests).To(BeEmpty())
+
+		source.Digest = digest([]byte("other"))
+		_, _, err = helm.FetchChart(ctx, source, options)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fetch charts from chart repositories", func() {
+		source, err := helm.ParseChartSource(server.URL + "/repo#mychart")
+		Expect(err).NotTo(HaveOccurred())
+		fsys, chartPath, err := helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("2.0.0"))
+
+		source, err = helm.ParseChartSource(server.URL + "/repo#mychart:~1.0")
+		Expect(err).NotTo(HaveOccurred())
+		fsys, chartPath, err = helm.FetchChart(ctx, source, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(readVersion(fsys, chartPath)).To(Equal("1.0.0"))
+
+		source.Digest = digest(archives["1.1.0"])
+		_, _, err = helm.FetchChart(ctx, source, options)
+		Expect(err).To(MatchError(ContainSubstring("does not match the requested digest")))
+
+		generator, err := helm.NewHelmGeneratorForSource(ctx, &helm.ChartSource{URL: server.URL + "/repo", Name: "mychart", Version: "1.1.0"}, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(generator).NotTo(BeNil())
+	})
+
+	It("should reject requests without valid credentials", func() {
+		options.Credentials = nil
+		_, _, err := helm.FetchChart(ctx, &helm.ChartSource{URL: server.URL + "/repo", Name: "mychart"}, options)
+		Expect(err).To(MatchError(ContainSubstring("401")))
+		_, _, err = helm.FetchChart(ctx, &helm.ChartSource{URL: strings.Replace(server.URL, "https://", "oci://", 1) + "/charts/mychart", Version: "1.0.0"}, options)
+		Expect(err).To(MatchError(ContainSubstring("401")))
+	})
+
+	It("should read credentials from secrets", func() {
+		credentials, err := helm.CredentialsFromSecret(&corev1.Secret{
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.io":{"auth":"dXNlcjpzZWNyZXQ="},"https://index.docker.io/v1/":{"username":"a","password":"b"}}}`),
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(Equal(helm.Credentials{
+			"registry.example.io": helm.Credential{Username: "user", Password: "secret"},
+			"index.docker.io":     helm.Credential{Username: "a", Password: "b"},
+		}))
+
+		credentials, err = helm.CredentialsFromSecret(&corev1.Secret{Data: map[string][]byte{"username": []byte("user"), "password": []byte("secret")}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(credentials).To(Equal(helm.Credentials{"": helm.Credential{Username: "user", Password: "secret"}}))
+	})
+
+	It("should parse chart sources", func() {
+		Expect(helm.ParseChartSource("oci://registry.example.io:5000/charts/mychart")).To(Equal(&helm.ChartSource{URL: "oci://registry.example.io:5000/charts/mychart"}))
+		Expect(helm.ParseChartSource("oci://registry.example.io/charts/mychart:1.2.3@sha256:abc")).To(Equal(&helm.ChartSource{URL: "oci://registry.example.io/charts/mychart", Version: "1.2.3", Digest: "sha256:abc"}))
+		Expect(helm.ParseChartSource("https://charts.example.io#mychart:~1.2")).To(Equal(&helm.ChartSource{URL: "https://charts.example.io", Name: "mychart", Version: "~1.2"}))
+		_, err := helm.ParseChartSource("https://charts.example.io")
+		Expect(err).To(HaveOccurred())
+		_, err = helm.ParseChartSource("/some/path")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func newTestChartArchive(name string, version string) []byte {
+	files := map[string]string{
+		name + "/Chart.yaml":               fmt.Sprintf("apiVersion: v2\nname: %s\nversion: %s\n", name, version),
+		name + "/values.yaml":              "data: {}\n",
+		name + "/templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\n",
+	}
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for path, content := range files {
+		Expect(tarWriter.WriteHeader(&tar.Header{Name: path, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tarWriter.Write([]byte(content))
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+func digest(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"io"
 	"io/fs"
+	"text/template"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
@@ -34,6 +35,10 @@ type KustomizeGeneratorOptions struct {
 	RightTemplateDelimiter *string
 	// If defined, used to decrypt files
 	Decryptor manifests.Decryptor
+	// If defined, custom template functions; they must not collide with any existing function (that is, the sprig functions, the functions
+	// provided by this module, such as toYaml, and the functions bound at render time, such as include, tpl, lookup, localLookup, readFile
+	// or component); collisions are reported as error by NewKustomizeGenerator()
+	FuncMap template.FuncMap
 }
 
 // KustomizeGenerator is a Generator implementation that basically renders a given Kustomization.
@@ -46,8 +51,6 @@ type KustomizeGenerator struct {
 
 var _ manifests.Generator = &KustomizeGenerator{}
 
-// TODO: add a way to pass custom template functions
-
 // Create a new KustomizeGenerator.
 // The client parameter is deprecated (ignored) and will be removed in a future release.
 // If fsys is nil, the local operating system filesystem will be used, and kustomizationPath can be an absolute or relative path (in the latter case it will be considered
@@ -60,6 +63,7 @@ func NewKustomizeGenerator(fsys fs.FS, kustomizationPath string, _ client.Client
 		LeftTemplateDelimiter:  options.LeftTemplateDelimiter,
 		RightTemplateDelimiter: options.RightTemplateDelimiter,
 		Decryptor:              options.Decryptor,
+		FuncMap:                options.FuncMap,
 	})
 	if err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ package manifests
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/fs"
 	"os"
@@ -27,6 +28,7 @@ import (
 	"sigs.k8s.io/kustomize/kyaml/resid"
 	kyaml "sigs.k8s.io/yaml"
 
+	"github.com/sap/component-operator-runtime/internal/jsonschema"
 	"github.com/sap/component-operator-runtime/internal/templatex"
 	"github.com/sap/component-operator-runtime/pkg/types"
 )
@@ -82,6 +84,47 @@ func (t *TemplateParameterTransformer) TransformParameters(namespace string, nam
 	return transformedParameters, nil
 }
 
+// SchemaParameterTransformer validates parameters against a given JSON schema; the parameters are passed through unchanged.
+// Schemas not declaring a dialect (through $schema) are considered to be draft 2020-12; references to external schema documents are not supported.
+type SchemaParameterTransformer struct {
+	schema *jsonschema.Schema
+}
+
+var _ ParameterTransformer = &SchemaParameterTransformer{}
+
+// Create a new SchemaParameterTransformer (reading the schema, as JSON or YAML, from the given fsys and path).
+// If fsys is nil, the local OS filesystem will be used.
+func NewSchemaParameterTransformer(fsys fs.FS, path string) (*SchemaParameterTransformer, error) {
+	if fsys == nil {
+		fsys = os.DirFS("/")
+		absolutePath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		path = absolutePath[1:]
+	}
+
+	raw, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := jsonschema.Compile(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SchemaParameterTransformer{schema: schema}, nil
+}
+
+// Validate parameters; the returned error lists all violations, along with the paths of the offending fields.
+func (t *SchemaParameterTransformer) TransformParameters(namespace string, name string, parameters types.Unstructurable) (types.Unstructurable, error) {
+	if err := t.schema.Validate(parameters.ToUnstructured()); err != nil {
+		return nil, fmt.Errorf("parameters do not match schema: %w", err)
+	}
+	return parameters, nil
+}
+
 type SubstitutionObjectTransformer struct {
 	substitutions map[string]string
 	selector      types.Selector[client.Object]
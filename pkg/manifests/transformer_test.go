@@ -0,0 +1,54 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package manifests_test
+
+import (
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/manifests"
+	"github.com/sap/component-operator-runtime/pkg/types"
+)
+
+var _ = Describe("testing: transformer.go", func() {
+	Context("testing: SchemaParameterTransformer", func() {
+		var transformer *manifests.SchemaParameterTransformer
+
+		BeforeEach(func() {
+			fsys := fstest.MapFS{
+				"schema.yaml": &fstest.MapFile{Data: []byte(`
+type: object
+properties:
+  replicas:
+    type: integer
+  image:
+    type: object
+    properties:
+      tag:
+        type: string
+    required:
+    - tag
+`)},
+			}
+			var err error
+			transformer, err = manifests.NewSchemaParameterTransformer(fsys, "schema.yaml")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should pass valid parameters through unchanged", func() {
+			parameters := types.UnstructurableMap{"replicas": int64(1), "image": map[string]any{"tag": "latest"}}
+			Expect(transformer.TransformParameters("default", "test", parameters)).To(Equal(parameters))
+		})
+
+		It("should reject invalid parameters with field paths", func() {
+			_, err := transformer.TransformParameters("default", "test", types.UnstructurableMap{"replicas": "one", "image": map[string]any{}})
+			Expect(err).To(MatchError(ContainSubstring("replicas: ")))
+			Expect(err).To(MatchError(ContainSubstring("image: missing property 'tag'")))
+		})
+	})
+})
@@ -23,6 +23,30 @@ type Generator interface {
 	Generate(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, error)
 }
 
+// Interface for generators which declare outputs.
+// Outputs are values read from dependent objects after the component became ready; the component reconciler
+// publishes them in the component's status and (optionally) in a connection secret.
+// Note that dependent objects may declare outputs by annotations as well, so implementing this interface is
+// only necessary if outputs cannot (or shall not) be declared that way.
+type OutputGenerator interface {
+	Generator
+	GetOutputs(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]Output, error)
+}
+
+// Interface for generators which provide usage notes (such as the NOTES.txt of a Helm chart).
+// Notes are rendered after the component became ready; the component reconciler publishes them in the component's status.
+type NotesGenerator interface {
+	Generator
+	GetNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) (string, error)
+}
+
+// Interface for generators which render usage notes along with the resource descriptors (in a single pass).
+// If implemented, the component reconciler calls GenerateWithNotes() instead of calling Generate() and GetNotes() separately.
+type CombinedNotesGenerator interface {
+	NotesGenerator
+	GenerateWithNotes(ctx context.Context, namespace string, name string, parameters types.Unstructurable) ([]client.Object, string, error)
+}
+
 // Interface for generators that can be enhanced with parameter/object transformers.
 type TransformableGenerator interface {
 	Generator
@@ -48,6 +72,20 @@ type Decryptor interface {
 	Decrypt(input []byte, path string) ([]byte, error)
 }
 
+// Output declaration.
+type Output struct {
+	// Name of the output; used as key in the component's status outputs, resp. in the connection secret.
+	Name string
+	// Dependent object from which the value is read; if the object is namespaced but has no namespace set,
+	// the namespace passed to the generator is assumed.
+	Object types.ObjectKey
+	// JSONPath template (in kubectl syntax, such as {.spec.clusterIP}) which is evaluated against the dependent object.
+	// If the dependent object is a secret, values read from its data are base64-decoded.
+	Path string
+	// Whether the output is sensitive; sensitive outputs are only written to the connection secret, but not to the status.
+	Sensitive bool
+}
+
 // +kubebuilder:object:generate=true
 
 // Kustomize patch specification, basically a subset of sigs.k8s.io/kustomize/api/types#Patch
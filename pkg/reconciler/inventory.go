@@ -42,6 +42,12 @@ func (i InventoryItem) Matches(key types.ObjectKey) bool {
 	return i.GroupVersionKind().GroupKind() == key.GetObjectKind().GroupVersionKind().GroupKind() && i.Namespace == key.GetNamespace() && i.Name == key.GetName()
 }
 
+// Check whether the dependent object is a regular dependent, as opposed to a test hook or delete hook object;
+// only regular dependents take part in the readiness of the owning component.
+func (i InventoryItem) IsRegular() bool {
+	return !isTestItem(&i) && !isHookItem(&i)
+}
+
 // Return a string representation of the inventory item; makes InventoryItem implement the Stringer interface.
 func (i InventoryItem) String() string {
 	return types.ObjectKeyToString(&i)
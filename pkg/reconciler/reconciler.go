@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/iancoleman/strcase"
@@ -18,6 +19,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sap/go-generics/sets"
 	"github.com/sap/go-generics/slices"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -63,6 +67,10 @@ const (
 	digestOnce = "__once__"
 )
 
+const (
+	tracerName = "github.com/sap/component-operator-runtime/pkg/reconciler"
+)
+
 const (
 	defaultReapplyInterval = 60 * time.Minute
 )
@@ -93,6 +101,18 @@ var deletePolicyByAnnotation = map[string]DeletePolicy{
 	types.DeletePolicyOrphanOnDelete: DeletePolicyOrphanOnDelete,
 }
 
+var deleteHookByAnnotation = map[string]DeleteHook{
+	types.DeleteHookPreDelete:  DeleteHookPreDelete,
+	types.DeleteHookPostDelete: DeleteHookPostDelete,
+}
+
+var deleteHookCleanupPolicyByAnnotation = map[string]DeleteHookCleanupPolicy{
+	types.DeleteHookCleanupPolicyNever:     DeleteHookCleanupPolicyNever,
+	types.DeleteHookCleanupPolicyOnSuccess: DeleteHookCleanupPolicyOnSuccess,
+	types.DeleteHookCleanupPolicyOnFailure: DeleteHookCleanupPolicyOnFailure,
+	types.DeleteHookCleanupPolicyAlways:    DeleteHookCleanupPolicyAlways,
+}
+
 // ReconcilerOptions are creation options for a Reconciler.
 type ReconcilerOptions struct {
 	// Which field manager to use in API calls.
@@ -131,6 +151,9 @@ type ReconcilerOptions struct {
 	// Whether to disable sending events on the dependent objects.
 	// If unspecified, true is assumed.
 	EnableEvents *bool
+	// Which tracer provider to use to create spans for apply/delete waves and object operations.
+	// If unspecified, the global tracer provider is used.
+	TracerProvider trace.TracerProvider
 }
 
 // ReconcilerMetrics defines metrics that the reconciler can populate.
@@ -144,30 +167,34 @@ type ReconcilerMetrics struct {
 
 // Reconciler manages specified objects in the given target cluster.
 type Reconciler struct {
-	fieldOwner                   string
-	finalizer                    string
-	client                       cluster.Client
-	statusAnalyzer               status.StatusAnalyzer
-	metrics                      ReconcilerMetrics
-	adoptionPolicy               AdoptionPolicy
-	reconcilePolicy              ReconcilePolicy
-	updatePolicy                 UpdatePolicy
-	deletePolicy                 DeletePolicy
-	missingNamespacesPolicy      MissingNamespacesPolicy
-	additionalManagedTypes       []TypeInfo
-	reapplyInterval              time.Duration
-	enableEvents                 bool
-	labelKeyOwnerId              string
-	annotationKeyOwnerId         string
-	annotationKeyDigest          string
-	annotationKeyAdoptionPolicy  string
-	annotationKeyReconcilePolicy string
-	annotationKeyUpdatePolicy    string
-	annotationKeyDeletePolicy    string
-	annotationKeyReapplyInterval string
-	annotationKeyApplyOrder      string
-	annotationKeyPurgeOrder      string
-	annotationKeyDeleteOrder     string
+	fieldOwner                           string
+	finalizer                            string
+	client                               cluster.Client
+	statusAnalyzer                       status.StatusAnalyzer
+	metrics                              ReconcilerMetrics
+	tracer                               trace.Tracer
+	adoptionPolicy                       AdoptionPolicy
+	reconcilePolicy                      ReconcilePolicy
+	updatePolicy                         UpdatePolicy
+	deletePolicy                         DeletePolicy
+	missingNamespacesPolicy              MissingNamespacesPolicy
+	additionalManagedTypes               []TypeInfo
+	reapplyInterval                      time.Duration
+	enableEvents                         bool
+	labelKeyOwnerId                      string
+	annotationKeyOwnerId                 string
+	annotationKeyDigest                  string
+	annotationKeyAdoptionPolicy          string
+	annotationKeyReconcilePolicy         string
+	annotationKeyUpdatePolicy            string
+	annotationKeyDeletePolicy            string
+	annotationKeyReapplyInterval         string
+	annotationKeyApplyOrder              string
+	annotationKeyPurgeOrder              string
+	annotationKeyDeleteOrder             string
+	annotationKeyDeleteHook              string
+	annotationKeyDeleteHookCleanupPolicy string
+	annotationKeyTestHook                string
 }
 
 // Create new reconciler.
@@ -202,32 +229,39 @@ func NewReconciler(name string, clnt cluster.Client, options ReconcilerOptions)
 	if options.EnableEvents == nil {
 		options.EnableEvents = new(true)
 	}
+	if options.TracerProvider == nil {
+		options.TracerProvider = otel.GetTracerProvider()
+	}
 
 	return &Reconciler{
-		fieldOwner:                   *options.FieldOwner,
-		finalizer:                    *options.Finalizer,
-		client:                       clnt,
-		statusAnalyzer:               options.StatusAnalyzer,
-		metrics:                      options.Metrics,
-		adoptionPolicy:               *options.AdoptionPolicy,
-		reconcilePolicy:              ReconcilePolicyOnObjectChange,
-		updatePolicy:                 *options.UpdatePolicy,
-		deletePolicy:                 *options.DeletePolicy,
-		missingNamespacesPolicy:      *options.MissingNamespacesPolicy,
-		additionalManagedTypes:       options.AdditionalManagedTypes,
-		reapplyInterval:              *options.ReapplyInterval,
-		enableEvents:                 *options.EnableEvents,
-		labelKeyOwnerId:              name + "/" + types.LabelKeySuffixOwnerId,
-		annotationKeyOwnerId:         name + "/" + types.AnnotationKeySuffixOwnerId,
-		annotationKeyDigest:          name + "/" + types.AnnotationKeySuffixDigest,
-		annotationKeyAdoptionPolicy:  name + "/" + types.AnnotationKeySuffixAdoptionPolicy,
-		annotationKeyReconcilePolicy: name + "/" + types.AnnotationKeySuffixReconcilePolicy,
-		annotationKeyUpdatePolicy:    name + "/" + types.AnnotationKeySuffixUpdatePolicy,
-		annotationKeyDeletePolicy:    name + "/" + types.AnnotationKeySuffixDeletePolicy,
-		annotationKeyReapplyInterval: name + "/" + types.AnnotationKeySuffixReapplyInterval,
-		annotationKeyApplyOrder:      name + "/" + types.AnnotationKeySuffixApplyOrder,
-		annotationKeyPurgeOrder:      name + "/" + types.AnnotationKeySuffixPurgeOrder,
-		annotationKeyDeleteOrder:     name + "/" + types.AnnotationKeySuffixDeleteOrder,
+		fieldOwner:                           *options.FieldOwner,
+		finalizer:                            *options.Finalizer,
+		client:                               clnt,
+		statusAnalyzer:                       options.StatusAnalyzer,
+		metrics:                              options.Metrics,
+		tracer:                               options.TracerProvider.Tracer(tracerName),
+		adoptionPolicy:                       *options.AdoptionPolicy,
+		reconcilePolicy:                      ReconcilePolicyOnObjectChange,
+		updatePolicy:                         *options.UpdatePolicy,
+		deletePolicy:                         *options.DeletePolicy,
+		missingNamespacesPolicy:              *options.MissingNamespacesPolicy,
+		additionalManagedTypes:               options.AdditionalManagedTypes,
+		reapplyInterval:                      *options.ReapplyInterval,
+		enableEvents:                         *options.EnableEvents,
+		labelKeyOwnerId:                      name + "/" + types.LabelKeySuffixOwnerId,
+		annotationKeyOwnerId:                 name + "/" + types.AnnotationKeySuffixOwnerId,
+		annotationKeyDigest:                  name + "/" + types.AnnotationKeySuffixDigest,
+		annotationKeyAdoptionPolicy:          name + "/" + types.AnnotationKeySuffixAdoptionPolicy,
+		annotationKeyReconcilePolicy:         name + "/" + types.AnnotationKeySuffixReconcilePolicy,
+		annotationKeyUpdatePolicy:            name + "/" + types.AnnotationKeySuffixUpdatePolicy,
+		annotationKeyDeletePolicy:            name + "/" + types.AnnotationKeySuffixDeletePolicy,
+		annotationKeyReapplyInterval:         name + "/" + types.AnnotationKeySuffixReapplyInterval,
+		annotationKeyApplyOrder:              name + "/" + types.AnnotationKeySuffixApplyOrder,
+		annotationKeyPurgeOrder:              name + "/" + types.AnnotationKeySuffixPurgeOrder,
+		annotationKeyDeleteOrder:             name + "/" + types.AnnotationKeySuffixDeleteOrder,
+		annotationKeyDeleteHook:              name + "/" + types.AnnotationKeySuffixDeleteHook,
+		annotationKeyDeleteHookCleanupPolicy: name + "/" + types.AnnotationKeySuffixDeleteHookCleanupPolicy,
+		annotationKeyTestHook:                name + "/" + types.AnnotationKeySuffixTestHook,
 	}
 }
 
@@ -275,132 +309,21 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 
 	hashedOwnerId := util.Sha256base32([]byte(ownerId))
 
-	// perform some initial validation
-	for _, object := range objects {
-		if object.GetGenerateName() != "" {
-			// TODO: the object key string representation below will probably be incomplete because of missing metadata.name
-			return false, fmt.Errorf("object %s specifies metadata.generateName (but dependent objects are not allowed to do so)", types.ObjectKeyToString(object))
-		}
-	}
-
-	// normalize objects; that means:
-	// - check that unstructured objects have valid type information set, and convert them to their concrete type if known to the scheme
-	// - check that non-unstructured types are known to the scheme, and validate/set their type information
-	objects, err = normalizeObjects(objects, r.client.Scheme())
+	// validate and normalize objects
+	objects, err = r.prepareObjects(objects, namespace)
 	if err != nil {
-		return false, legacyerrors.Wrap(err, "error normalizing objects")
-	}
-
-	// merge secret stringData into data; this is required/better because server-side-apply does not work well
-	// with stringData
-	for _, object := range objects {
-		if isSecret(object) {
-			secret := object.(*corev1.Secret)
-			for k, v := range secret.StringData {
-				if secret.Data == nil {
-					secret.Data = make(map[string][]byte)
-				}
-				secret.Data[k] = []byte(v)
-			}
-			secret.StringData = nil
-		}
-	}
-
-	// perform cleanup on object manifests
-	for _, object := range objects {
-		util.RemoveLabel(object, r.labelKeyOwnerId)
-		util.RemoveAnnotation(object, r.annotationKeyOwnerId)
-		util.RemoveAnnotation(object, r.annotationKeyDigest)
-	}
-
-	// validate type and set namespace for namespaced objects which have no namespace set
-	// TODO: this could be moved into normalizeObjects (which would require the rest mapper to be passed there)
-	for _, object := range objects {
-		// note: due to the normalization done before, every object will now have a valid object kind set
-		gvk := object.GetObjectKind().GroupVersionKind()
-
-		// TODO: client now has a method IsObjectNamespaced(); can we use this instead?
-		scope := scopeUnknown
-		restMapping, err := r.client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
-		if err == nil {
-			scope = scopeFromRestMapping(restMapping)
-		} else if !apimeta.IsNoMatchError(err) {
-			return false, legacyerrors.Wrapf(err, "error getting rest mapping for object %s", types.ObjectKeyToString(object))
-		}
-		for _, crd := range getCrds(objects) {
-			if crd.Spec.Group == gvk.Group && crd.Spec.Names.Kind == gvk.Kind {
-				// TODO: validate that scope obtained from crd matches scope from rest mapping (if one was found there)
-				scope = scopeFromCrd(crd)
-				err = nil
-				break
-			}
-		}
-		for _, apiService := range getApiServices(objects) {
-			if apiService.Spec.Group == gvk.Group && apiService.Spec.Version == gvk.Version {
-				err = nil
-				break
-			}
-		}
-		if err != nil {
-			return false, legacyerrors.Wrapf(err, "error getting rest mapping for object %s", types.ObjectKeyToString(object))
-		}
-
-		if object.GetNamespace() == "" && scope == scopeNamespaced {
-			object.SetNamespace(namespace)
-		}
-		if object.GetNamespace() != "" && scope == scopeCluster {
-			object.SetNamespace("")
-		}
-	}
-	// note: after this point there still can be objects in the list which
-	// - have a namespace set although they are not namespaced
-	// - do not have a namespace set although they are namespaced
-	// which exactly happens if
-	// 1. the object is incorrectly specified and
-	// 2. calling RESTMapping() above returned a NoMatchError (i.e. the type is currently not known to the api server) and
-	// 3. the type belongs to a (new) api service which is part of the inventory
-	// such entries can cause trouble, e.g. because the duplicate check, or InventoryItem.Match() might not work reliably ...
-	// TODO: should we allow at all that api services and according instances are deployed together?
-
-	// check that there are no duplicate objects
-	// TODO: this could be moved to normalizeObjects()
-	objectKeys := sets.New[string]()
-	for _, object := range objects {
-		objectKey := fmt.Sprintf("%s %s/%s", object.GetObjectKind().GroupVersionKind().GroupKind(), object.GetNamespace(), object.GetName())
-		if sets.Contains(objectKeys, objectKey) {
-			return false, fmt.Errorf("duplicate object %s", objectKey)
-		}
-		sets.Add(objectKeys, objectKey)
+		return false, err
 	}
 
-	// validate annotations
-	for _, object := range objects {
-		if _, err := r.getAdoptionPolicy(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getReconcilePolicy(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getUpdatePolicy(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getDeletePolicy(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getReapplyInterval(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getApplyOrder(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getPurgeOrder(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		if _, err := r.getDeleteOrder(object); err != nil {
-			return false, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
-		}
-		// TODO: should status-hint be validated here as well?
-	}
+	// skip delete hooks and test hooks; delete hooks will be created when the component is deleted, test hooks are run by Test()
+	testObjects := slices.Select(objects, func(object client.Object) bool {
+		// note: this Must() is ok because we checked the generated objects above
+		return util.Must(r.isTestHook(object))
+	})
+	objects = slices.Select(objects, func(object client.Object) bool {
+		// note: these Must() are ok because we checked the generated objects above
+		return util.Must(r.getDeleteHook(object)) == DeleteHookNone && !util.Must(r.isTestHook(object))
+	})
 
 	// define getter functions for later usage
 	getAdoptionPolicy := func(object client.Object) AdoptionPolicy {
@@ -532,6 +455,15 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 				break
 			}
 		}
+		if isTestItem(item) {
+			// note: test items are maintained by Test(); they are only deleted here if the according test hook disappeared
+			for _, object := range testObjects {
+				if item.Matches(object) {
+					found = true
+					break
+				}
+			}
+		}
 		if !found && item.Digest != "" {
 			item.Digest = ""
 			item.Phase = PhaseScheduledForDeletion
@@ -686,6 +618,8 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 	numRegularToBeApplied := 0
 	numLateToBeApplied := 0
 	numUnready := 0
+	waveCtx, waveSpan := ctx, trace.Span(nil)
+	defer func() { util.EndSpan(waveSpan, nil) }()
 	for k, object := range objects {
 		// retrieve inventory item corresponding to this object
 		item := mustGetItem(*inventory, object)
@@ -703,6 +637,7 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 		// count instances of managed types in this order which are about to be applied
 		if k == 0 || getApplyOrder(objects[k-1]) < applyOrder {
 			log.V(2).Info("begin of apply wave", "order", applyOrder)
+			waveCtx, waveSpan = r.tracer.Start(ctx, "ApplyWave", trace.WithAttributes(attribute.Int("order", applyOrder)))
 			numRegularToBeApplied = 0
 			numLateToBeApplied = 0
 			for j := k; j < len(objects) && getApplyOrder(objects[j]) == applyOrder; j++ {
@@ -728,7 +663,7 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 			// note: here, phase is one of PhaseScheduledForApplication, PhaseCreating, PhaseUpdating, PhaseReady
 			if isRegular(object) || isLate(object) && numRegularToBeApplied == 0 || isManaged(object) && numRegularToBeApplied == 0 && numLateToBeApplied == 0 {
 				// fetch object (if existing)
-				existingObject, err := r.readObject(ctx, item)
+				existingObject, err := r.readObject(waveCtx, item)
 				if err != nil {
 					return false, legacyerrors.Wrapf(err, "error reading object %s", item)
 				}
@@ -741,7 +676,7 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 				reapplyInterval := getReapplyInterval(object)
 				now := time.Now()
 				if existingObject == nil {
-					if err := r.createObject(ctx, object, nil, updatePolicy); err != nil {
+					if err := r.createObject(waveCtx, object, nil, updatePolicy); err != nil {
 						return false, legacyerrors.Wrapf(err, "error creating object %s", item)
 					}
 					item.Phase = PhaseCreating
@@ -753,12 +688,12 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 					(existingObject.GetAnnotations()[r.annotationKeyDigest] != item.Digest || item.LastAppliedAt == nil || item.LastAppliedAt.Time.Before(now.Add(-reapplyInterval))) {
 					switch updatePolicy {
 					case UpdatePolicyRecreate:
-						if err := r.deleteObject(ctx, object, existingObject, hashedOwnerId); err != nil {
+						if err := r.deleteObject(waveCtx, object, existingObject, hashedOwnerId); err != nil {
 							return false, legacyerrors.Wrapf(err, "error deleting (while recreating) object %s", item)
 						}
 					default:
 						// TODO: perform an additional owner id check
-						if err := r.updateObject(ctx, object, existingObject, nil, updatePolicy); err != nil {
+						if err := r.updateObject(waveCtx, object, existingObject, nil, updatePolicy); err != nil {
 							return false, legacyerrors.Wrapf(err, "error updating object %s", item)
 						}
 					}
@@ -795,6 +730,8 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 		// - otherwise trigger another reconcile
 		if k == len(objects)-1 || getApplyOrder(objects[k+1]) > applyOrder {
 			log.V(2).Info("end of apply wave", "order", applyOrder)
+			util.EndSpan(waveSpan, nil)
+			waveSpan = nil
 			if numUnready == 0 {
 				numPurged := 0
 				for j := 0; j <= k; j++ {
@@ -836,6 +773,7 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 		// count instances of managed types in this wave which are about to be deleted
 		if k == 0 || (*inventory)[k-1].DeleteOrder < item.DeleteOrder {
 			log.V(2).Info("begin of deletion wave", "order", item.DeleteOrder)
+			waveCtx, waveSpan = r.tracer.Start(ctx, "DeletionWave", trace.WithAttributes(attribute.Int("order", item.DeleteOrder)))
 			numManagedToBeDeleted = 0
 			for j := k; j < len(*inventory) && (*inventory)[j].DeleteOrder == item.DeleteOrder; j++ {
 				_item := (*inventory)[j]
@@ -847,7 +785,7 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 
 		if item.Phase == PhaseScheduledForDeletion || item.Phase == PhaseDeleting {
 			// fetch object (if existing)
-			existingObject, err := r.readObject(ctx, item)
+			existingObject, err := r.readObject(waveCtx, item)
 			if err != nil {
 				return false, legacyerrors.Wrapf(err, "error reading object %s", item)
 			}
@@ -868,14 +806,14 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 						(existingObject != nil && existingObject.GetLabels()[r.labelKeyOwnerId] != hashedOwnerId)
 
 					if orphan {
-						if err := r.orphanObject(ctx, existingObject, hashedOwnerId); err != nil {
+						if err := r.orphanObject(waveCtx, existingObject, hashedOwnerId); err != nil {
 							return false, legacyerrors.Wrapf(err, "error orphaning object %s", item)
 						}
 						item.Phase = ""
 					} else {
 						// note: here is a theoretical risk that we delete an existing foreign object, because informers are not yet synced
 						// however not sending the delete request is also not an option, because this might lead to orphaned own dependents
-						if err := r.deleteObject(ctx, item, existingObject, hashedOwnerId); err != nil {
+						if err := r.deleteObject(waveCtx, item, existingObject, hashedOwnerId); err != nil {
 							return false, legacyerrors.Wrapf(err, "error deleting object %s", item)
 						}
 						item.Phase = PhaseDeleting
@@ -910,6 +848,8 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 		// trigger another reconcile if this is the last object of the wave, and some deletions are not yet finished
 		if k == len(*inventory)-1 || (*inventory)[k+1].DeleteOrder > item.DeleteOrder {
 			log.V(2).Info("end of deletion wave", "order", item.DeleteOrder)
+			util.EndSpan(waveSpan, nil)
+			waveSpan = nil
 			if numToBeDeleted > 0 {
 				break
 			}
@@ -926,137 +866,677 @@ func (r *Reconciler) Apply(ctx context.Context, inventory *[]*InventoryItem, obj
 	return true, nil
 }
 
-// Delete objects stored in the inventory from the target cluster and maintain inventory.
-// Objects will be deleted in waves, according to their delete order (as stored in the inventory); that means, the deletion of
-// objects having a certain delete order will only start if all objects with lower delete order are gone. Within a wave, objects are
-// deleted following a certain internal ordering; in particular, if there are instances of types which are part of the wave, then these
-// instances will be deleted first; only if all such instances are gone, the remaining objects of the wave will be deleted.
-// Objects which have an effective Orphan or OrphanOnDelete deletion policy will not be touched (remain in the cluster),
-// but will no longer appear in the inventory.
+// Calculate the changes which a subsequent call to Apply() (with the same arguments) would trigger, without touching the target cluster
+// or the passed inventory. The returned plan contains
+//   - a create action for every object which is not yet contained in the inventory
+//   - an update action for every object which is contained in the inventory, but whose digest changed
+//   - a delete action for every object which is contained in the inventory, but not in the passed objects.
 //
-// This method will change the passed inventory (remove elements, change elements). If Delete() returns true, then all objects are gone; otherwise,
-// if it returns false, the caller should recall it timely, until it returns true. In any case, the passed inventory should match the state of the
-// inventory after the previous invocation of Delete(); usually, the caller saves the inventory after calling Delete(), and loads it before calling Delete().
-func (r *Reconciler) Delete(ctx context.Context, inventory *[]*InventoryItem, ownerId string) (bool, error) {
-	log := log.FromContext(ctx)
-
-	hashedOwnerId := util.Sha256base32([]byte(ownerId))
+// Note that the plan is based on the inventory only (not on the live state of the objects in the target cluster); in particular,
+// drift of dependent objects in the cluster is not reflected in the plan.
+func (r *Reconciler) Plan(ctx context.Context, inventory []*InventoryItem, objects []client.Object, namespace string, componentDigest string) ([]PlanItem, error) {
+	objects, err := r.prepareObjects(objects, namespace)
+	if err != nil {
+		return nil, err
+	}
 
-	// delete objects and maintain inventory;
-	// objects are deleted in waves according to their delete order;
-	// that means, only if all objects of a wave are gone, the next wave will be processed;
-	// within each wave, objects which are instances of managed types are deleted before all
-	// other objects, and namespaces will only be deleted if they are not used by any
-	// object in the inventory (note that this may cause deadlocks)
-	numManagedToBeDeleted := 0
-	numToBeDeleted := 0
-	for k, item := range *inventory {
-		// if this is the first object of an order, then
-		// count instances of managed types in this wave which are about to be deleted
-		if k == 0 || (*inventory)[k-1].DeleteOrder < item.DeleteOrder {
-			log.V(2).Info("begin of deletion wave", "order", item.DeleteOrder)
-			numManagedToBeDeleted = 0
-			for j := k; j < len(*inventory) && (*inventory)[j].DeleteOrder == item.DeleteOrder; j++ {
-				_item := (*inventory)[j]
-				if isManagedInstance(r.additionalManagedTypes, *inventory, _item) {
-					numManagedToBeDeleted++
-				}
-			}
+	var plan []PlanItem
+	for _, object := range objects {
+		if util.Must(r.getDeleteHook(object)) != DeleteHookNone || util.Must(r.isTestHook(object)) {
+			// note: delete hooks and test hooks are not applied, so they do not appear in the plan
+			continue
 		}
-
-		// fetch object (if existing)
-		existingObject, err := r.readObject(ctx, item)
+		reconcilePolicy := util.Must(r.getReconcilePolicy(object))
+		digest, err := calculateObjectDigest(object, componentDigest, reconcilePolicy)
 		if err != nil {
-			return false, legacyerrors.Wrapf(err, "error reading object %s", item)
+			return nil, legacyerrors.Wrapf(err, "error calculating digest for object %s", types.ObjectKeyToString(object))
 		}
-
-		switch item.Phase {
-		case PhaseDeleting:
-			if existingObject == nil {
-				// if object is gone, we can remove it from inventory
-				item.Phase = ""
-			} else if !existingObject.GetDeletionTimestamp().IsZero() {
-				// object is still there and deleting, waiting until it goes away
-				numToBeDeleted++
-			} else if existingObject.GetLabels()[r.labelKeyOwnerId] != hashedOwnerId {
-				// object is there but not deleting; if we are not owning it that means that somebody else has
-				// recreated it in the meantime; so we consider this as not our problem and remove it from inventory
-				log.V(1).Info("orphaning resurrected object (probably it was recreated by someone else)", "key", types.ObjectKeyToString(item))
-				item.Phase = ""
-			} else {
-				// object is there, not deleting, but we own it; that is really strange and should actually not happen
-				return false, fmt.Errorf("object %s was already deleted but has no deletion timestamp", types.ObjectKeyToString(item))
-			}
-		default:
-			orphan := item.DeletePolicy == DeletePolicyOrphan || item.DeletePolicy == DeletePolicyOrphanOnDelete ||
-				(existingObject != nil && existingObject.GetLabels()[r.labelKeyOwnerId] != hashedOwnerId)
-
-			// delete namespaces after all contained inventory items
-			// delete all instances of managed types before remaining objects; this ensures that no objects are prematurely
-			// deleted which are needed for the deletion of the managed instances, such as webhook servers, api servers, ...
-			if (!isNamespace(item) || !isNamespaceUsed(*inventory, item.Name)) && (numManagedToBeDeleted == 0 || isManagedInstance(r.additionalManagedTypes, *inventory, item)) {
-				if orphan {
-					if err := r.orphanObject(ctx, existingObject, hashedOwnerId); err != nil {
-						return false, legacyerrors.Wrapf(err, "error orphaning object %s", item)
-					}
-					item.Phase = ""
-				} else {
-					// delete the object
-					// note: here is a theoretical risk that we delete an existing (foreign) object, because informers are not yet synced
-					// however not sending the delete request is also not an option, because this might lead to orphaned own dependents
-					if err := r.deleteObject(ctx, item, existingObject, hashedOwnerId); err != nil {
-						return false, legacyerrors.Wrapf(err, "error deleting object %s", item)
-					}
-					item.Phase = PhaseDeleting
-					item.Status = status.TerminatingStatus
-					numToBeDeleted++
-				}
-			} else {
-				numToBeDeleted++
-			}
+		gvk := object.GetObjectKind().GroupVersionKind()
+		planItem := PlanItem{
+			TypeVersionInfo: TypeVersionInfo{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+			NameInfo:        NameInfo{Namespace: object.GetNamespace(), Name: object.GetName()},
 		}
-
-		// trigger another reconcile if this is the last object of the wave, and some deletions are not yet completed
-		if k == len(*inventory)-1 || (*inventory)[k+1].DeleteOrder > item.DeleteOrder {
-			log.V(2).Info("end of deletion wave", "order", item.DeleteOrder)
-			if numToBeDeleted > 0 {
+		if item := getItem(inventory, object); item == nil {
+			planItem.Action = PlanActionCreate
+		} else if item.Digest != digest {
+			planItem.Action = PlanActionUpdate
+		} else {
+			continue
+		}
+		plan = append(plan, planItem)
+	}
+	for _, item := range inventory {
+		if item.Digest == "" {
+			// note: item is already being deleted
+			continue
+		}
+		found := false
+		for _, object := range objects {
+			if item.Matches(object) {
+				found = true
 				break
 			}
 		}
+		if !found {
+			plan = append(plan, PlanItem{
+				TypeVersionInfo: item.TypeVersionInfo,
+				NameInfo:        item.NameInfo,
+				Action:          PlanActionDelete,
+			})
+		}
 	}
 
-	*inventory = slices.Select(*inventory, func(item *InventoryItem) bool { return item.Phase != "" })
-
-	return len(*inventory) == 0, nil
+	return plan, nil
 }
 
-// Check if the object set defined by inventory is ready for deletion; that means: check if the inventory contains
-// types (as custom resource definition or from an api service), while there exist instances of these types in the cluster,
-// which are not contained in the inventory. There is one exception of this rule: if all objects in the inventory have their
-// deletion policy set to Orphan or OrphanOnDelete, then the deletion of the component is immediately allowed.
-func (r *Reconciler) IsDeletionAllowed(ctx context.Context, inventory *[]*InventoryItem, ownerId string) (bool, string, error) {
-	hashedOwnerId := util.Sha256base32([]byte(ownerId))
+// Validate the passed objects, without persisting any changes to the target cluster or touching any inventory.
+// Besides the static checks also performed by Apply() (such as type and annotation validation, or duplicate detection),
+// if dryRun is true, every object is sent to the target cluster by a server-side-apply dry-run request; in that case, objects whose
+// type or namespace does not (yet) exist in the target cluster are skipped (since they might be created by the objects themselves).
+func (r *Reconciler) Validate(ctx context.Context, objects []client.Object, namespace string, dryRun bool) error {
+	objects, err := r.prepareObjects(objects, namespace)
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		return nil
+	}
 
-	for _, t := range r.additionalManagedTypes {
-		gk := schema.GroupKind(t)
-		used, err := r.isTypeUsed(ctx, gk, hashedOwnerId, true)
+	for _, object := range objects {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
 		if err != nil {
-			return false, "", legacyerrors.Wrapf(err, "error checking usage of type %s", gk)
+			return legacyerrors.Wrapf(err, "error converting object %s", types.ObjectKeyToString(object))
 		}
-		if used {
-			return false, fmt.Sprintf("type %s is still in use (instances exist)", gk), nil
+		obj := &unstructured.Unstructured{Object: data}
+		obj.SetManagedFields(nil)
+		obj.SetResourceVersion("")
+		if err := r.client.Patch(ctx, obj, client.Apply, client.FieldOwner(r.fieldOwner), client.ForceOwnership, client.DryRunAll); err != nil {
+			if apimeta.IsNoMatchError(err) || (apierrors.IsNotFound(err) && object.GetNamespace() != "") {
+				continue
+			}
+			return legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
 		}
 	}
 
-	if slices.All(*inventory, func(item *InventoryItem) bool {
-		return item.DeletePolicy == DeletePolicyOrphan || item.DeletePolicy == DeletePolicyOrphanOnDelete
-	}) {
-		return true, "", nil
-	}
+	return nil
+}
 
-	for _, item := range *inventory {
-		switch {
-		case isCrd(item):
+// Run the tests contained in the passed objects; that is, objects having the test-hook annotation set; all other objects are ignored.
+// Test objects are created in waves, according to their apply order; the next wave will only be processed if all tests of the previous waves
+// succeeded. Tests are (re-)run whenever their digest changes; since the passed componentDigest is included into that digest, this means
+// in particular that all tests are re-run if the component digest changes. Before re-running a test, an existing test object will be deleted.
+// Test objects are maintained in the inventory (with phase ScheduledForTest, Testing, TestSucceeded or TestFailed); they remain in the cluster
+// until the test is re-run, or until the test hook disappears, or the component is deleted.
+//
+// This method will change the passed inventory (add elements, change elements). If Test() returns true, then all tests are completed; in that case,
+// the returned string is empty if all tests succeeded, and describes the failed tests otherwise. If Test() returns false, the caller should re-call
+// it timely, until it returns true. Test() should only be called after Apply() returned true.
+func (r *Reconciler) Test(ctx context.Context, inventory *[]*InventoryItem, objects []client.Object, namespace string, ownerId string, componentDigest string) (bool, string, error) {
+	var err error
+	log := log.FromContext(ctx)
+
+	hashedOwnerId := util.Sha256base32([]byte(ownerId))
+
+	// validate and normalize objects, and retrieve tests from them
+	objects, err = r.prepareObjects(objects, namespace)
+	if err != nil {
+		return false, "", err
+	}
+	tests := slices.Select(objects, func(object client.Object) bool {
+		// note: this Must() is ok because we checked the generated objects above
+		return util.Must(r.isTestHook(object))
+	})
+	if len(tests) == 0 {
+		return true, "", nil
+	}
+
+	// define getter functions for later usage
+	getAdoptionPolicy := func(object client.Object) AdoptionPolicy {
+		// note: this Must() is ok because we checked the generated objects above, and this function will be called for these objects only
+		return util.Must(r.getAdoptionPolicy(object))
+	}
+	getUpdatePolicy := func(object client.Object) UpdatePolicy {
+		// note: this Must() is ok because we checked the generated objects above, and this function will be called for these objects only
+		return util.Must(r.getUpdatePolicy(object))
+	}
+	getDeletePolicy := func(object client.Object) DeletePolicy {
+		// note: this Must() is ok because we checked the generated objects above, and this function will be called for these objects only
+		return util.Must(r.getDeletePolicy(object))
+	}
+	getApplyOrder := func(object client.Object) int {
+		// note: this Must() is ok because we checked the generated objects above, and this function will be called for these objects only
+		return util.Must(r.getApplyOrder(object))
+	}
+	getDeleteOrder := func(object client.Object) int {
+		// note: this Must() is ok because we checked the generated objects above, and this function will be called for these objects only
+		return util.Must(r.getDeleteOrder(object))
+	}
+
+	// add (or reschedule) tests in the inventory; if there were any tests added, return, such that the inventory gets persisted
+	// before the test objects are created
+	numAdded := 0
+	for _, test := range tests {
+		digest, err := calculateObjectDigest(test, componentDigest, ReconcilePolicyOnObjectOrComponentChange)
+		if err != nil {
+			return false, "", legacyerrors.Wrapf(err, "error calculating digest for object %s", types.ObjectKeyToString(test))
+		}
+		item := getItem(*inventory, test)
+		if item == nil {
+			existingObject, err := r.readObject(ctx, test)
+			if err != nil {
+				return false, "", legacyerrors.Wrapf(err, "error reading object %s", types.ObjectKeyToString(test))
+			}
+			// check ownership
+			if existingObject != nil {
+				adoptionPolicy := getAdoptionPolicy(test)
+				existingOwnerId := existingObject.GetLabels()[r.labelKeyOwnerId]
+				if existingOwnerId == "" {
+					if adoptionPolicy != AdoptionPolicyIfUnowned && adoptionPolicy != AdoptionPolicyAlways {
+						return false, "", fmt.Errorf("found existing object %s without owner", types.ObjectKeyToString(test))
+					}
+				} else if existingOwnerId != hashedOwnerId {
+					if adoptionPolicy != AdoptionPolicyAlways {
+						return false, "", fmt.Errorf("owner conflict; object %s is owned by %s", types.ObjectKeyToString(test), existingObject.GetAnnotations()[r.annotationKeyOwnerId])
+					}
+				}
+			}
+			gvk := test.GetObjectKind().GroupVersionKind()
+			item = &InventoryItem{
+				TypeVersionInfo: TypeVersionInfo{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+				NameInfo:        NameInfo{Namespace: test.GetNamespace(), Name: test.GetName()},
+				ReconcilePolicy: ReconcilePolicyOnObjectOrComponentChange,
+			}
+			*inventory = append(*inventory, item)
+			numAdded++
+		} else if !isTestItem(item) {
+			return false, "", fmt.Errorf("test hook %s must not be a regular dependent object", types.ObjectKeyToString(test))
+		}
+		item.AdoptionPolicy = getAdoptionPolicy(test)
+		item.UpdatePolicy = getUpdatePolicy(test)
+		item.DeletePolicy = getDeletePolicy(test)
+		item.ApplyOrder = getApplyOrder(test)
+		item.DeleteOrder = getDeleteOrder(test)
+		if digest != item.Digest {
+			item.Digest = digest
+			item.Phase = PhaseScheduledForTest
+			item.Status = status.InProgressStatus
+		}
+	}
+	if numAdded > 0 {
+		*inventory = sortObjectsForDelete(*inventory)
+		return false, "", nil
+	}
+
+	// run tests in waves according to their apply order; that means, only if all tests of a wave succeeded,
+	// the next wave will be processed
+	tests = sortObjectsForApply(tests, getApplyOrder)
+	numUnready := 0
+	var failed []string
+	for k, test := range tests {
+		item := mustGetItem(*inventory, test)
+		applyOrder := getApplyOrder(test)
+
+		if k == 0 || getApplyOrder(tests[k-1]) < applyOrder {
+			log.V(2).Info("begin of test wave", "order", applyOrder)
+			numUnready = 0
+		}
+
+		if item.Phase == PhaseScheduledForTest || item.Phase == PhaseTesting {
+			existingObject, err := r.readObject(ctx, item)
+			if err != nil {
+				return false, "", legacyerrors.Wrapf(err, "error reading object %s", item)
+			}
+
+			util.SetLabel(test, r.labelKeyOwnerId, hashedOwnerId)
+			util.SetAnnotation(test, r.annotationKeyOwnerId, ownerId)
+			util.SetAnnotation(test, r.annotationKeyDigest, item.Digest)
+
+			switch {
+			case existingObject == nil && item.Phase == PhaseScheduledForTest:
+				if err := r.createObject(ctx, test, nil, getUpdatePolicy(test)); err != nil {
+					return false, "", legacyerrors.Wrapf(err, "error creating object %s", item)
+				}
+				item.Phase = PhaseTesting
+				item.Status = status.InProgressStatus
+				item.LastAppliedAt = &metav1.Time{Time: time.Now()}
+			case existingObject == nil:
+				// test object disappeared while the test was running; re-run the test
+				item.Phase = PhaseScheduledForTest
+			case !existingObject.GetDeletionTimestamp().IsZero():
+				// object is still there and deleting (e.g. because of a previous test run), waiting until it goes away
+			case item.Phase == PhaseScheduledForTest || existingObject.GetAnnotations()[r.annotationKeyDigest] != item.Digest:
+				// object is left over from a previous test run; delete it, such that the test is re-run
+				if err := r.deleteObject(ctx, item, existingObject, hashedOwnerId); err != nil {
+					return false, "", legacyerrors.Wrapf(err, "error deleting (while recreating) object %s", item)
+				}
+				item.Phase = PhaseScheduledForTest
+			default:
+				existingStatus, err := r.statusAnalyzer.ComputeStatus(existingObject)
+				if err != nil {
+					return false, "", legacyerrors.Wrapf(err, "error checking status of object %s", item)
+				}
+				if isPod(existingObject) {
+					// note: other than regular dependent pods, test pods are only considered as succeeded if they ran to completion
+					phase, _, _ := unstructured.NestedString(existingObject.Object, "status", "phase")
+					switch corev1.PodPhase(phase) {
+					case corev1.PodSucceeded:
+						existingStatus = status.CurrentStatus
+					case corev1.PodFailed:
+						existingStatus = status.FailedStatus
+					default:
+						existingStatus = status.InProgressStatus
+					}
+				}
+				item.Status = existingStatus
+				switch existingStatus {
+				case status.CurrentStatus:
+					item.Phase = PhaseTestSucceeded
+				case status.FailedStatus:
+					item.Phase = PhaseTestFailed
+				}
+			}
+		}
+
+		switch item.Phase {
+		case PhaseTestSucceeded:
+		case PhaseTestFailed:
+			failed = append(failed, types.ObjectKeyToString(item))
+		default:
+			numUnready++
+		}
+
+		// trigger another reconcile if this is the last test of the wave, and some tests are not yet completed;
+		// if some tests of the wave failed, skip the remaining waves
+		if k == len(tests)-1 || getApplyOrder(tests[k+1]) > applyOrder {
+			log.V(2).Info("end of test wave", "order", applyOrder)
+			if numUnready > 0 {
+				return false, "", nil
+			}
+			if len(failed) > 0 {
+				break
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return true, fmt.Sprintf("test(s) failed: %s", strings.Join(failed, ", ")), nil
+	}
+	return true, "", nil
+}
+
+// Delete objects stored in the inventory from the target cluster and maintain inventory.
+// Objects will be deleted in waves, according to their delete order (as stored in the inventory); that means, the deletion of
+// objects having a certain delete order will only start if all objects with lower delete order are gone. Within a wave, objects are
+// deleted following a certain internal ordering; in particular, if there are instances of types which are part of the wave, then these
+// instances will be deleted first; only if all such instances are gone, the remaining objects of the wave will be deleted.
+// Objects which have an effective Orphan or OrphanOnDelete deletion policy will not be touched (remain in the cluster),
+// but will no longer appear in the inventory.
+//
+// This method will change the passed inventory (remove elements, change elements). If Delete() returns true, then all objects are gone; otherwise,
+// if it returns false, the caller should recall it timely, until it returns true. In any case, the passed inventory should match the state of the
+// inventory after the previous invocation of Delete(); usually, the caller saves the inventory after calling Delete(), and loads it before calling Delete().
+func (r *Reconciler) Delete(ctx context.Context, inventory *[]*InventoryItem, ownerId string) (bool, error) {
+	return r.DeleteWithHooks(ctx, inventory, nil, "", ownerId)
+}
+
+// Same as Delete(), but in addition run the delete hooks contained in the passed objects; that is, objects having the delete-hook
+// annotation set; all other objects are ignored. Pre-delete hooks are created (in waves, according to their apply order), and awaited to
+// become ready, before the objects stored in the inventory are deleted; post-delete hooks are created and awaited after all objects stored
+// in the inventory are gone. Hook objects are added to the inventory while they are processed (with phase RunningHook or HookSucceeded).
+// If a hook object fails, its inventory item is set to phase HookFailed, and an error is returned; the failed hook object is deleted if its
+// update policy is Recreate, or its delete-hook-cleanup-policy is OnFailure or Always. Failed hooks are not retried; that is, subsequent invocations
+// of DeleteWithHooks() will return an error (and not touch any other object) as long as the inventory contains an item with phase HookFailed.
+// Callers may resolve this situation by calling Delete() instead, which skips the delete hooks.
+// Hook objects which became ready are deleted if their delete-hook-cleanup-policy is OnSuccess or Always;
+// otherwise they remain in the cluster (without being tracked any more in the inventory) after all hooks are completed.
+func (r *Reconciler) DeleteWithHooks(ctx context.Context, inventory *[]*InventoryItem, objects []client.Object, namespace string, ownerId string) (bool, error) {
+	var err error
+
+	hashedOwnerId := util.Sha256base32([]byte(ownerId))
+
+	// validate and normalize objects, and retrieve delete hooks from them
+	objects, err = r.prepareObjects(objects, namespace)
+	if err != nil {
+		return false, err
+	}
+	var preDeleteHooks, postDeleteHooks []client.Object
+	for _, object := range objects {
+		// note: this Must() is ok because we checked the generated objects above
+		switch util.Must(r.getDeleteHook(object)) {
+		case DeleteHookPreDelete:
+			preDeleteHooks = append(preDeleteHooks, object)
+		case DeleteHookPostDelete:
+			postDeleteHooks = append(postDeleteHooks, object)
+		}
+	}
+
+	// split inventory into hook items and regular items; the regular items are deleted as usual, the hook items are
+	// kept at the end of the inventory, until all hooks are completed
+	hookItems := slices.Select(*inventory, isHookItem)
+	items := slices.Select(*inventory, func(item *InventoryItem) bool { return !isHookItem(item) })
+	defer func() { *inventory = append(items, hookItems...) }()
+	for _, object := range objects {
+		if util.Must(r.getDeleteHook(object)) != DeleteHookNone && getItem(items, object) != nil {
+			return false, fmt.Errorf("delete hook %s must not be contained in the inventory", types.ObjectKeyToString(object))
+		}
+	}
+
+	if ok, err := r.runDeleteHooks(ctx, &hookItems, preDeleteHooks, ownerId, hashedOwnerId); err != nil || !ok {
+		return false, err
+	}
+	if ok, err := r.deleteObjects(ctx, &items, hashedOwnerId); err != nil || !ok {
+		return false, err
+	}
+	if ok, err := r.runDeleteHooks(ctx, &hookItems, postDeleteHooks, ownerId, hashedOwnerId); err != nil || !ok {
+		return false, err
+	}
+
+	// all hooks are completed; remaining hook objects are orphaned
+	for _, item := range hookItems {
+		existingObject, err := r.readObject(ctx, item)
+		if err != nil {
+			return false, legacyerrors.Wrapf(err, "error reading object %s", item)
+		}
+		if existingObject != nil && existingObject.GetDeletionTimestamp().IsZero() {
+			if err := r.orphanObject(ctx, existingObject, hashedOwnerId); err != nil {
+				return false, legacyerrors.Wrapf(err, "error orphaning object %s", item)
+			}
+		}
+	}
+	hookItems = nil
+
+	return len(items) == 0, nil
+}
+
+// create the given delete hooks (in waves, according to their apply order), and wait until they are ready;
+// returns true if all given hooks are completed
+func (r *Reconciler) runDeleteHooks(ctx context.Context, hookItems *[]*InventoryItem, hooks []client.Object, ownerId string, hashedOwnerId string) (bool, error) {
+	log := log.FromContext(ctx)
+
+	if len(hooks) == 0 {
+		return true, nil
+	}
+
+	// define getter functions for later usage
+	getAdoptionPolicy := func(object client.Object) AdoptionPolicy {
+		// note: this Must() is ok because the hooks were validated by the caller
+		return util.Must(r.getAdoptionPolicy(object))
+	}
+	getUpdatePolicy := func(object client.Object) UpdatePolicy {
+		// note: this Must() is ok because the hooks were validated by the caller
+		return util.Must(r.getUpdatePolicy(object))
+	}
+	getApplyOrder := func(object client.Object) int {
+		// note: this Must() is ok because the hooks were validated by the caller
+		return util.Must(r.getApplyOrder(object))
+	}
+	getDeleteHookCleanupPolicy := func(object client.Object) DeleteHookCleanupPolicy {
+		// note: this Must() is ok because the hooks were validated by the caller
+		return util.Must(r.getDeleteHookCleanupPolicy(object))
+	}
+
+	// add hooks to the inventory; if there were any hooks added, return, such that the inventory gets persisted
+	// before the hook objects are created
+	numAdded := 0
+	for _, hook := range hooks {
+		if getItem(*hookItems, hook) != nil {
+			continue
+		}
+		existingObject, err := r.readObject(ctx, hook)
+		if err != nil {
+			return false, legacyerrors.Wrapf(err, "error reading object %s", types.ObjectKeyToString(hook))
+		}
+		// check ownership
+		if existingObject != nil {
+			adoptionPolicy := getAdoptionPolicy(hook)
+			existingOwnerId := existingObject.GetLabels()[r.labelKeyOwnerId]
+			if existingOwnerId == "" {
+				if adoptionPolicy != AdoptionPolicyIfUnowned && adoptionPolicy != AdoptionPolicyAlways {
+					return false, fmt.Errorf("found existing object %s without owner", types.ObjectKeyToString(hook))
+				}
+			} else if existingOwnerId != hashedOwnerId {
+				if adoptionPolicy != AdoptionPolicyAlways {
+					return false, fmt.Errorf("owner conflict; object %s is owned by %s", types.ObjectKeyToString(hook), existingObject.GetAnnotations()[r.annotationKeyOwnerId])
+				}
+			}
+		}
+		gvk := hook.GetObjectKind().GroupVersionKind()
+		*hookItems = append(*hookItems, &InventoryItem{
+			TypeVersionInfo: TypeVersionInfo{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+			NameInfo:        NameInfo{Namespace: hook.GetNamespace(), Name: hook.GetName()},
+			AdoptionPolicy:  getAdoptionPolicy(hook),
+			ReconcilePolicy: ReconcilePolicyOnce,
+			UpdatePolicy:    getUpdatePolicy(hook),
+			DeletePolicy:    DeletePolicyDelete,
+			ApplyOrder:      getApplyOrder(hook),
+			Phase:           PhaseRunningHook,
+			Status:          status.InProgressStatus,
+		})
+		numAdded++
+	}
+	if numAdded > 0 {
+		return false, nil
+	}
+
+	// run hooks in waves according to their apply order; that means, only if all hooks of a wave are completed,
+	// the next wave will be processed
+	hooks = sortObjectsForApply(hooks, getApplyOrder)
+	numUnready := 0
+	for k, hook := range hooks {
+		item := mustGetItem(*hookItems, hook)
+		applyOrder := getApplyOrder(hook)
+
+		if k == 0 || getApplyOrder(hooks[k-1]) < applyOrder {
+			log.V(2).Info("begin of delete hook wave", "order", applyOrder)
+			numUnready = 0
+		}
+
+		if item.Phase == PhaseHookFailed {
+			// failed hooks are not retried (the caller must decide how to proceed)
+			return false, fmt.Errorf("delete hook %s failed", item)
+		}
+
+		if item.Phase != PhaseHookSucceeded {
+			existingObject, err := r.readObject(ctx, item)
+			if err != nil {
+				return false, legacyerrors.Wrapf(err, "error reading object %s", item)
+			}
+
+			util.SetLabel(hook, r.labelKeyOwnerId, hashedOwnerId)
+			util.SetAnnotation(hook, r.annotationKeyOwnerId, ownerId)
+
+			updatePolicy := getUpdatePolicy(hook)
+			cleanupPolicy := getDeleteHookCleanupPolicy(hook)
+			switch {
+			case existingObject == nil:
+				if err := r.createObject(ctx, hook, nil, updatePolicy); err != nil {
+					return false, legacyerrors.Wrapf(err, "error creating object %s", item)
+				}
+				item.Status = status.InProgressStatus
+				item.LastAppliedAt = &metav1.Time{Time: time.Now()}
+				numUnready++
+			case !existingObject.GetDeletionTimestamp().IsZero():
+				// object is still there and deleting (e.g. because it failed before), waiting until it goes away
+				numUnready++
+			case item.LastAppliedAt == nil:
+				// object exists, but was not (yet) created by us as a hook (e.g. it is a leftover of an earlier run)
+				if updatePolicy == UpdatePolicyRecreate {
+					if err := r.deleteObject(ctx, item, existingObject, hashedOwnerId); err != nil {
+						return false, legacyerrors.Wrapf(err, "error deleting (while recreating) object %s", item)
+					}
+				} else {
+					if err := r.updateObject(ctx, hook, existingObject, nil, updatePolicy); err != nil {
+						return false, legacyerrors.Wrapf(err, "error updating object %s", item)
+					}
+					item.LastAppliedAt = &metav1.Time{Time: time.Now()}
+				}
+				item.Status = status.InProgressStatus
+				numUnready++
+			default:
+				existingStatus, err := r.statusAnalyzer.ComputeStatus(existingObject)
+				if err != nil {
+					return false, legacyerrors.Wrapf(err, "error checking status of object %s", item)
+				}
+				item.Status = existingStatus
+				switch existingStatus {
+				case status.CurrentStatus:
+					item.Phase = PhaseHookSucceeded
+					if cleanupPolicy == DeleteHookCleanupPolicyOnSuccess || cleanupPolicy == DeleteHookCleanupPolicyAlways {
+						if err := r.deleteObject(ctx, item, existingObject, hashedOwnerId); err != nil {
+							return false, legacyerrors.Wrapf(err, "error deleting object %s", item)
+						}
+					}
+				case status.FailedStatus:
+					// note: the failure is terminal; recreating the hook (e.g. because of its update policy) would just make it fail again, and again
+					item.Phase = PhaseHookFailed
+					if cleanupPolicy == DeleteHookCleanupPolicyOnFailure || cleanupPolicy == DeleteHookCleanupPolicyAlways || updatePolicy == UpdatePolicyRecreate {
+						if err := r.deleteObject(ctx, item, existingObject, hashedOwnerId); err != nil {
+							return false, legacyerrors.Wrapf(err, "error deleting object %s", item)
+						}
+					}
+					return false, fmt.Errorf("delete hook %s failed", item)
+				default:
+					numUnready++
+				}
+			}
+		}
+
+		// trigger another reconcile if this is the last hook of the wave, and some hooks are not yet completed
+		if k == len(hooks)-1 || getApplyOrder(hooks[k+1]) > applyOrder {
+			log.V(2).Info("end of delete hook wave", "order", applyOrder)
+			if numUnready > 0 {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// delete objects stored in the inventory, as described in Delete()
+func (r *Reconciler) deleteObjects(ctx context.Context, inventory *[]*InventoryItem, hashedOwnerId string) (bool, error) {
+	log := log.FromContext(ctx)
+
+	// delete objects and maintain inventory;
+	// objects are deleted in waves according to their delete order;
+	// that means, only if all objects of a wave are gone, the next wave will be processed;
+	// within each wave, objects which are instances of managed types are deleted before all
+	// other objects, and namespaces will only be deleted if they are not used by any
+	// object in the inventory (note that this may cause deadlocks)
+	numManagedToBeDeleted := 0
+	numToBeDeleted := 0
+	waveCtx, waveSpan := ctx, trace.Span(nil)
+	defer func() { util.EndSpan(waveSpan, nil) }()
+	for k, item := range *inventory {
+		// if this is the first object of an order, then
+		// count instances of managed types in this wave which are about to be deleted
+		if k == 0 || (*inventory)[k-1].DeleteOrder < item.DeleteOrder {
+			log.V(2).Info("begin of deletion wave", "order", item.DeleteOrder)
+			waveCtx, waveSpan = r.tracer.Start(ctx, "DeletionWave", trace.WithAttributes(attribute.Int("order", item.DeleteOrder)))
+			numManagedToBeDeleted = 0
+			for j := k; j < len(*inventory) && (*inventory)[j].DeleteOrder == item.DeleteOrder; j++ {
+				_item := (*inventory)[j]
+				if isManagedInstance(r.additionalManagedTypes, *inventory, _item) {
+					numManagedToBeDeleted++
+				}
+			}
+		}
+
+		// fetch object (if existing)
+		existingObject, err := r.readObject(waveCtx, item)
+		if err != nil {
+			return false, legacyerrors.Wrapf(err, "error reading object %s", item)
+		}
+
+		switch item.Phase {
+		case PhaseDeleting:
+			if existingObject == nil {
+				// if object is gone, we can remove it from inventory
+				item.Phase = ""
+			} else if !existingObject.GetDeletionTimestamp().IsZero() {
+				// object is still there and deleting, waiting until it goes away
+				numToBeDeleted++
+			} else if existingObject.GetLabels()[r.labelKeyOwnerId] != hashedOwnerId {
+				// object is there but not deleting; if we are not owning it that means that somebody else has
+				// recreated it in the meantime; so we consider this as not our problem and remove it from inventory
+				log.V(1).Info("orphaning resurrected object (probably it was recreated by someone else)", "key", types.ObjectKeyToString(item))
+				item.Phase = ""
+			} else {
+				// object is there, not deleting, but we own it; that is really strange and should actually not happen
+				return false, fmt.Errorf("object %s was already deleted but has no deletion timestamp", types.ObjectKeyToString(item))
+			}
+		default:
+			orphan := item.DeletePolicy == DeletePolicyOrphan || item.DeletePolicy == DeletePolicyOrphanOnDelete ||
+				(existingObject != nil && existingObject.GetLabels()[r.labelKeyOwnerId] != hashedOwnerId)
+
+			// delete namespaces after all contained inventory items
+			// delete all instances of managed types before remaining objects; this ensures that no objects are prematurely
+			// deleted which are needed for the deletion of the managed instances, such as webhook servers, api servers, ...
+			if (!isNamespace(item) || !isNamespaceUsed(*inventory, item.Name)) && (numManagedToBeDeleted == 0 || isManagedInstance(r.additionalManagedTypes, *inventory, item)) {
+				if orphan {
+					if err := r.orphanObject(waveCtx, existingObject, hashedOwnerId); err != nil {
+						return false, legacyerrors.Wrapf(err, "error orphaning object %s", item)
+					}
+					item.Phase = ""
+				} else {
+					// delete the object
+					// note: here is a theoretical risk that we delete an existing (foreign) object, because informers are not yet synced
+					// however not sending the delete request is also not an option, because this might lead to orphaned own dependents
+					if err := r.deleteObject(waveCtx, item, existingObject, hashedOwnerId); err != nil {
+						return false, legacyerrors.Wrapf(err, "error deleting object %s", item)
+					}
+					item.Phase = PhaseDeleting
+					item.Status = status.TerminatingStatus
+					numToBeDeleted++
+				}
+			} else {
+				numToBeDeleted++
+			}
+		}
+
+		// trigger another reconcile if this is the last object of the wave, and some deletions are not yet completed
+		if k == len(*inventory)-1 || (*inventory)[k+1].DeleteOrder > item.DeleteOrder {
+			log.V(2).Info("end of deletion wave", "order", item.DeleteOrder)
+			util.EndSpan(waveSpan, nil)
+			waveSpan = nil
+			if numToBeDeleted > 0 {
+				break
+			}
+		}
+	}
+
+	*inventory = slices.Select(*inventory, func(item *InventoryItem) bool { return item.Phase != "" })
+
+	return len(*inventory) == 0, nil
+}
+
+// Check if the object set defined by inventory is ready for deletion; that means: check if the inventory contains
+// types (as custom resource definition or from an api service), while there exist instances of these types in the cluster,
+// which are not contained in the inventory. There is one exception of this rule: if all objects in the inventory have their
+// deletion policy set to Orphan or OrphanOnDelete, then the deletion of the component is immediately allowed.
+func (r *Reconciler) IsDeletionAllowed(ctx context.Context, inventory *[]*InventoryItem, ownerId string) (bool, string, error) {
+	hashedOwnerId := util.Sha256base32([]byte(ownerId))
+
+	for _, t := range r.additionalManagedTypes {
+		gk := schema.GroupKind(t)
+		used, err := r.isTypeUsed(ctx, gk, hashedOwnerId, true)
+		if err != nil {
+			return false, "", legacyerrors.Wrapf(err, "error checking usage of type %s", gk)
+		}
+		if used {
+			return false, fmt.Sprintf("type %s is still in use (instances exist)", gk), nil
+		}
+	}
+
+	if slices.All(*inventory, func(item *InventoryItem) bool {
+		return item.DeletePolicy == DeletePolicyOrphan || item.DeletePolicy == DeletePolicyOrphanOnDelete
+	}) {
+		return true, "", nil
+	}
+
+	for _, item := range *inventory {
+		switch {
+		case isCrd(item):
 			crd := &apiextensionsv1.CustomResourceDefinition{}
 			if err := r.client.Get(ctx, apitypes.NamespacedName{Name: item.GetName()}, crd); err != nil {
 				if apierrors.IsNotFound(err) {
@@ -1096,6 +1576,156 @@ func (r *Reconciler) IsDeletionAllowed(ctx context.Context, inventory *[]*Invent
 	return true, "", nil
 }
 
+// validate and normalize given objects; that means:
+//   - check type information, and convert unstructured objects to their concrete type if known to the scheme
+//   - merge secret stringData into data
+//   - remove owner id and digest metadata from the manifests
+//   - default namespace of namespaced objects (to the given namespace), and clear namespace of cluster-scoped objects
+//   - check that there are no duplicates, and that the annotations of the objects are valid
+func (r *Reconciler) prepareObjects(objects []client.Object, namespace string) ([]client.Object, error) {
+	var err error
+
+	// perform some initial validation
+	for _, object := range objects {
+		if object.GetGenerateName() != "" {
+			// TODO: the object key string representation below will probably be incomplete because of missing metadata.name
+			return nil, fmt.Errorf("object %s specifies metadata.generateName (but dependent objects are not allowed to do so)", types.ObjectKeyToString(object))
+		}
+	}
+
+	// normalize objects; that means:
+	// - check that unstructured objects have valid type information set, and convert them to their concrete type if known to the scheme
+	// - check that non-unstructured types are known to the scheme, and validate/set their type information
+	objects, err = normalizeObjects(objects, r.client.Scheme())
+	if err != nil {
+		return nil, legacyerrors.Wrap(err, "error normalizing objects")
+	}
+
+	// merge secret stringData into data; this is required/better because server-side-apply does not work well
+	// with stringData
+	for _, object := range objects {
+		if isSecret(object) {
+			secret := object.(*corev1.Secret)
+			for k, v := range secret.StringData {
+				if secret.Data == nil {
+					secret.Data = make(map[string][]byte)
+				}
+				secret.Data[k] = []byte(v)
+			}
+			secret.StringData = nil
+		}
+	}
+
+	// perform cleanup on object manifests
+	for _, object := range objects {
+		util.RemoveLabel(object, r.labelKeyOwnerId)
+		util.RemoveAnnotation(object, r.annotationKeyOwnerId)
+		util.RemoveAnnotation(object, r.annotationKeyDigest)
+	}
+
+	// validate type and set namespace for namespaced objects which have no namespace set
+	// TODO: this could be moved into normalizeObjects (which would require the rest mapper to be passed there)
+	for _, object := range objects {
+		// note: due to the normalization done before, every object will now have a valid object kind set
+		gvk := object.GetObjectKind().GroupVersionKind()
+
+		// TODO: client now has a method IsObjectNamespaced(); can we use this instead?
+		scope := scopeUnknown
+		restMapping, err := r.client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err == nil {
+			scope = scopeFromRestMapping(restMapping)
+		} else if !apimeta.IsNoMatchError(err) {
+			return nil, legacyerrors.Wrapf(err, "error getting rest mapping for object %s", types.ObjectKeyToString(object))
+		}
+		for _, crd := range getCrds(objects) {
+			if crd.Spec.Group == gvk.Group && crd.Spec.Names.Kind == gvk.Kind {
+				// TODO: validate that scope obtained from crd matches scope from rest mapping (if one was found there)
+				scope = scopeFromCrd(crd)
+				err = nil
+				break
+			}
+		}
+		for _, apiService := range getApiServices(objects) {
+			if apiService.Spec.Group == gvk.Group && apiService.Spec.Version == gvk.Version {
+				err = nil
+				break
+			}
+		}
+		if err != nil {
+			return nil, legacyerrors.Wrapf(err, "error getting rest mapping for object %s", types.ObjectKeyToString(object))
+		}
+
+		if object.GetNamespace() == "" && scope == scopeNamespaced {
+			object.SetNamespace(namespace)
+		}
+		if object.GetNamespace() != "" && scope == scopeCluster {
+			object.SetNamespace("")
+		}
+	}
+	// note: after this point there still can be objects in the list which
+	// - have a namespace set although they are not namespaced
+	// - do not have a namespace set although they are namespaced
+	// which exactly happens if
+	// 1. the object is incorrectly specified and
+	// 2. calling RESTMapping() above returned a NoMatchError (i.e. the type is currently not known to the api server) and
+	// 3. the type belongs to a (new) api service which is part of the inventory
+	// such entries can cause trouble, e.g. because the duplicate check, or InventoryItem.Match() might not work reliably ...
+	// TODO: should we allow at all that api services and according instances are deployed together?
+
+	// check that there are no duplicate objects
+	// TODO: this could be moved to normalizeObjects()
+	objectKeys := sets.New[string]()
+	for _, object := range objects {
+		objectKey := fmt.Sprintf("%s %s/%s", object.GetObjectKind().GroupVersionKind().GroupKind(), object.GetNamespace(), object.GetName())
+		if sets.Contains(objectKeys, objectKey) {
+			return nil, fmt.Errorf("duplicate object %s", objectKey)
+		}
+		sets.Add(objectKeys, objectKey)
+	}
+
+	// validate annotations
+	for _, object := range objects {
+		if _, err := r.getAdoptionPolicy(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getReconcilePolicy(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getUpdatePolicy(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getDeletePolicy(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getReapplyInterval(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getApplyOrder(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getPurgeOrder(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getDeleteOrder(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getDeleteHook(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if _, err := r.getDeleteHookCleanupPolicy(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		}
+		if isTestHook, err := r.isTestHook(object); err != nil {
+			return nil, legacyerrors.Wrapf(err, "error validating object %s", types.ObjectKeyToString(object))
+		} else if isTestHook && util.Must(r.getDeleteHook(object)) != DeleteHookNone {
+			return nil, legacyerrors.Wrapf(fmt.Errorf("test hooks must not be delete hooks"), "error validating object %s", types.ObjectKeyToString(object))
+		}
+		// TODO: should status-hint be validated here as well?
+	}
+
+	return objects, nil
+}
+
 // reaad object and return as unstructured
 func (r *Reconciler) readObject(ctx context.Context, key types.ObjectKey) (*unstructured.Unstructured, error) {
 	if counter := r.metrics.ReadCounter; counter != nil {
@@ -1118,6 +1748,9 @@ func (r *Reconciler) readObject(ctx context.Context, key types.ObjectKey) (*unst
 // createdObject is optional; if non-nil, it will be populated with the created object; the same variable can be supplied as object and createObject;
 // if object is a crd or an api services, the reconciler's finalizer will be added
 func (r *Reconciler) createObject(ctx context.Context, object client.Object, createdObject any, updatePolicy UpdatePolicy) (err error) {
+	ctx, span := r.tracer.Start(ctx, "CreateObject", trace.WithAttributes(util.ObjectSpanAttributes(object)...))
+	defer func() { util.EndSpan(span, err) }()
+
 	if counter := r.metrics.CreateCounter; counter != nil {
 		counter.Inc()
 	}
@@ -1168,6 +1801,9 @@ func (r *Reconciler) createObject(ctx context.Context, object client.Object, cre
 // if updatePolicy equals UpdatePolicySsaOverride, then in addition, a preparation patch request will be performed before doing the conflict-forcing
 // server-side-apply patch; this preparation patch will adjust managedFields, reclaiming fields/values previously owned by kubectl
 func (r *Reconciler) updateObject(ctx context.Context, object client.Object, existingObject *unstructured.Unstructured, updatedObject any, updatePolicy UpdatePolicy) (err error) {
+	ctx, span := r.tracer.Start(ctx, "UpdateObject", trace.WithAttributes(util.ObjectSpanAttributes(object)...))
+	defer func() { util.EndSpan(span, err) }()
+
 	if counter := r.metrics.UpdateCounter; counter != nil {
 		counter.Inc()
 	}
@@ -1265,6 +1901,9 @@ func (r *Reconciler) updateObject(ctx context.Context, object client.Object, exi
 // finalizer (i.e. the finalizer equal to the reconciler name) will be cleared, such that the object can be physically
 // removed (unless other finalizers prevent this)
 func (r *Reconciler) deleteObject(ctx context.Context, key types.ObjectKey, existingObject *unstructured.Unstructured, hashedOwnerId string) (err error) {
+	ctx, span := r.tracer.Start(ctx, "DeleteObject", trace.WithAttributes(util.ObjectSpanAttributes(key)...))
+	defer func() { util.EndSpan(span, err) }()
+
 	if counter := r.metrics.DeleteCounter; counter != nil {
 		counter.Inc()
 	}
@@ -1491,6 +2130,42 @@ func (r *Reconciler) getDeleteOrder(object client.Object) (int, error) {
 	return deleteOrder, nil
 }
 
+func (r *Reconciler) getDeleteHook(object client.Object) (DeleteHook, error) {
+	deleteHook := strcase.ToKebab(object.GetAnnotations()[r.annotationKeyDeleteHook])
+	switch deleteHook {
+	case "":
+		return DeleteHookNone, nil
+	case types.DeleteHookPreDelete, types.DeleteHookPostDelete:
+		return deleteHookByAnnotation[deleteHook], nil
+	default:
+		return "", fmt.Errorf("invalid value for annotation %s: %s", r.annotationKeyDeleteHook, deleteHook)
+	}
+}
+
+func (r *Reconciler) getDeleteHookCleanupPolicy(object client.Object) (DeleteHookCleanupPolicy, error) {
+	cleanupPolicy := strcase.ToKebab(object.GetAnnotations()[r.annotationKeyDeleteHookCleanupPolicy])
+	switch cleanupPolicy {
+	case "":
+		return DeleteHookCleanupPolicyNever, nil
+	case types.DeleteHookCleanupPolicyNever, types.DeleteHookCleanupPolicyOnSuccess, types.DeleteHookCleanupPolicyOnFailure, types.DeleteHookCleanupPolicyAlways:
+		return deleteHookCleanupPolicyByAnnotation[cleanupPolicy], nil
+	default:
+		return "", fmt.Errorf("invalid value for annotation %s: %s", r.annotationKeyDeleteHookCleanupPolicy, cleanupPolicy)
+	}
+}
+
+func (r *Reconciler) isTestHook(object client.Object) (bool, error) {
+	value, ok := object.GetAnnotations()[r.annotationKeyTestHook]
+	if !ok {
+		return false, nil
+	}
+	isTestHook, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for annotation %s: %s", r.annotationKeyTestHook, value)
+	}
+	return isTestHook, nil
+}
+
 func (r *Reconciler) isTypeUsed(ctx context.Context, gk schema.GroupKind, hashedOwnerId string, onlyForeign bool) (bool, error) {
 	resLists, err := r.client.DiscoveryClient().ServerPreferredResources()
 	if err != nil {
@@ -1338,6 +1338,137 @@ var _ = Describe("testing: reconciler.go", func() {
 
 	})
 
+	Describe("testing: DeleteWithHooks()", func() {
+
+		It("should run pre-delete hooks before, and post-delete hooks after deleting objects", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "s",
+					Namespace: namespace,
+				},
+			}
+			preDeleteHook := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pre",
+					Namespace: namespace,
+					Annotations: map[string]string{
+						fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHook):              types.DeleteHookPreDelete,
+						fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHookCleanupPolicy): types.DeleteHookCleanupPolicyOnSuccess,
+					},
+				},
+			}
+			postDeleteHook := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "post",
+					Namespace: namespace,
+					Annotations: map[string]string{
+						fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHook): types.DeleteHookPostDelete,
+					},
+				},
+			}
+
+			objects := []client.Object{secret, preDeleteHook, postDeleteHook}
+			objectsToCleanup = objects
+
+			actualInventory := make([]*InventoryItem, 0)
+			for i := range 100 {
+				ok, err := reconciler.Apply(context.Background(), &actualInventory, objects, namespace, ownerId, componentDigest)
+				Expect(err).NotTo(HaveOccurred())
+				if ok {
+					break
+				}
+				if i == 99 {
+					Fail("object reconciliation did not complete after 100 iterations")
+				}
+			}
+
+			Expect(actualInventory).To(HaveLen(1))
+			err := env.EnsureObjectDoesNotExist(preDeleteHook)
+			Expect(err).NotTo(HaveOccurred())
+			err = env.EnsureObjectDoesNotExist(postDeleteHook)
+			Expect(err).NotTo(HaveOccurred())
+
+			ok, err := reconciler.DeleteWithHooks(context.Background(), &actualInventory, objects, namespace, ownerId)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(actualInventory).To(HaveLen(2))
+			Expect(getInventoryItemForObject(actualInventory, preDeleteHook).Phase).To(Equal(Phase(PhaseRunningHook)))
+
+			ok, err = reconciler.DeleteWithHooks(context.Background(), &actualInventory, objects, namespace, ownerId)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			_, err = env.EnsureObjectExists(preDeleteHook, reconcilerName, ownerId, "")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = env.EnsureObjectExists(secret, reconcilerName, ownerId, getInventoryItemForObject(actualInventory, secret).Digest)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := range 100 {
+				ok, err := reconciler.DeleteWithHooks(context.Background(), &actualInventory, objects, namespace, ownerId)
+				Expect(err).NotTo(HaveOccurred())
+				if ok {
+					break
+				}
+				if i == 99 {
+					Fail("object deletion did not complete after 100 iterations")
+				}
+			}
+
+			Expect(actualInventory).To(BeEmpty())
+			err = env.EnsureObjectDoesNotExist(secret)
+			Expect(err).NotTo(HaveOccurred())
+			err = env.EnsureObjectDoesNotExist(preDeleteHook)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = env.EnsureObjectExists(postDeleteHook, reconcilerName, ownerId, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+	})
+
+	Describe("testing: Plan()", func() {
+
+		It("should plan creations, updates and deletions based on the inventory", func() {
+			unchanged := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unchanged",
+					Namespace: namespace,
+				},
+			}
+			changed := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "changed",
+					Namespace: namespace,
+				},
+			}
+			added := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "added",
+					Namespace: namespace,
+				},
+			}
+			removed := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "removed",
+					Namespace: namespace,
+				},
+			}
+
+			inventory := make([]*InventoryItem, 0)
+			for _, obj := range []client.Object{unchanged, changed, removed} {
+				createInventoryItemForObject(&inventory, obj, PhaseReady, status.CurrentStatus)
+			}
+			changed.Data = map[string]string{"key": "value"}
+
+			plan, err := reconciler.Plan(context.Background(), inventory, []client.Object{unchanged, changed, added}, namespace, componentDigest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan).To(ConsistOf(
+				PlanItem{TypeVersionInfo: TypeVersionInfo{Version: "v1", Kind: "ConfigMap"}, NameInfo: NameInfo{Namespace: namespace, Name: "changed"}, Action: PlanActionUpdate},
+				PlanItem{TypeVersionInfo: TypeVersionInfo{Version: "v1", Kind: "ConfigMap"}, NameInfo: NameInfo{Namespace: namespace, Name: "added"}, Action: PlanActionCreate},
+				PlanItem{TypeVersionInfo: TypeVersionInfo{Version: "v1", Kind: "ConfigMap"}, NameInfo: NameInfo{Namespace: namespace, Name: "removed"}, Action: PlanActionDelete},
+			))
+		})
+
+	})
+
 	Describe("testing: IsDeletionAllowed()", func() {
 
 		It("should allow deletion if there are no foreign instances of managed types", func() {
@@ -1797,6 +1928,126 @@ var _ = Describe("testing: reconciler.go", func() {
 
 	})
 
+	Describe("testing: getDeleteHook()", func() {
+
+		var obj *corev1.ConfigMap
+
+		BeforeEach(func() {
+			obj = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cm",
+					Namespace:   namespace,
+					Annotations: map[string]string{},
+				},
+			}
+		})
+
+		It("if the annotation is not present, it should return DeleteHookNone", func() {
+			h, err := reconciler.getDeleteHook(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(DeleteHookNone))
+		})
+
+		It("if the annotation is present and valid, it should return the delete hook specified in the annotation", func() {
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHook)] = types.DeleteHookPreDelete
+			h, err := reconciler.getDeleteHook(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(DeleteHookPreDelete))
+
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHook)] = types.DeleteHookPostDelete
+			h, err = reconciler.getDeleteHook(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(h).To(Equal(DeleteHookPostDelete))
+		})
+
+		It("if the annotation is present but invalid, it should return an error", func() {
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHook)] = "invalid"
+			_, err := reconciler.getDeleteHook(obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+	})
+
+	Describe("testing: getDeleteHookCleanupPolicy()", func() {
+
+		var obj *corev1.ConfigMap
+
+		BeforeEach(func() {
+			obj = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cm",
+					Namespace:   namespace,
+					Annotations: map[string]string{},
+				},
+			}
+		})
+
+		It("if the annotation is not present, it should return DeleteHookCleanupPolicyNever", func() {
+			p, err := reconciler.getDeleteHookCleanupPolicy(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(Equal(DeleteHookCleanupPolicyNever))
+		})
+
+		It("if the annotation is present and valid, it should return the cleanup policy specified in the annotation", func() {
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHookCleanupPolicy)] = types.DeleteHookCleanupPolicyOnFailure
+			p, err := reconciler.getDeleteHookCleanupPolicy(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(Equal(DeleteHookCleanupPolicyOnFailure))
+
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHookCleanupPolicy)] = types.DeleteHookCleanupPolicyAlways
+			p, err = reconciler.getDeleteHookCleanupPolicy(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(Equal(DeleteHookCleanupPolicyAlways))
+		})
+
+		It("if the annotation is present but invalid, it should return an error", func() {
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixDeleteHookCleanupPolicy)] = "invalid"
+			_, err := reconciler.getDeleteHookCleanupPolicy(obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+	})
+
+	Describe("testing: isTestHook()", func() {
+
+		var obj *corev1.ConfigMap
+
+		BeforeEach(func() {
+			obj = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cm",
+					Namespace:   namespace,
+					Annotations: map[string]string{},
+				},
+			}
+		})
+
+		It("if the annotation is not present, it should return false", func() {
+			t, err := reconciler.isTestHook(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(BeFalse())
+		})
+
+		It("if the annotation is present and valid, it should return the value specified in the annotation", func() {
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixTestHook)] = "true"
+			t, err := reconciler.isTestHook(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(BeTrue())
+
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixTestHook)] = "false"
+			t, err = reconciler.isTestHook(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(t).To(BeFalse())
+		})
+
+		It("if the annotation is present but invalid, it should return an error", func() {
+			obj.Annotations[fmt.Sprintf("%s/%s", reconcilerName, types.AnnotationKeySuffixTestHook)] = "invalid"
+			_, err := reconciler.isTestHook(obj)
+			Expect(err).To(HaveOccurred())
+		})
+
+	})
+
 })
 
 func WithTypeInfo(obj client.Object, scheme *runtime.Scheme) (client.Object, error) {
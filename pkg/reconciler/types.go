@@ -92,6 +92,33 @@ const (
 	DeletePolicyOrphanOnDelete DeletePolicy = "OrphanOnDelete"
 )
 
+// DeleteHook defines whether a dependent object is a delete hook; delete hooks are not applied along with the other
+// dependent objects, but only when the component is deleted.
+type DeleteHook string
+
+const (
+	// The dependent object is not a delete hook.
+	DeleteHookNone DeleteHook = ""
+	// Create the dependent object, and wait for it to become ready, before the other dependent objects are deleted.
+	DeleteHookPreDelete DeleteHook = "PreDelete"
+	// Create the dependent object, and wait for it to become ready, after all other dependent objects are deleted.
+	DeleteHookPostDelete DeleteHook = "PostDelete"
+)
+
+// DeleteHookCleanupPolicy defines when the reconciler will delete the objects of delete hooks.
+type DeleteHookCleanupPolicy string
+
+const (
+	// Never delete the hook object; it is orphaned after all delete hooks have completed.
+	DeleteHookCleanupPolicyNever DeleteHookCleanupPolicy = "Never"
+	// Delete the hook object if it became ready.
+	DeleteHookCleanupPolicyOnSuccess DeleteHookCleanupPolicy = "OnSuccess"
+	// Delete the hook object if it failed.
+	DeleteHookCleanupPolicyOnFailure DeleteHookCleanupPolicy = "OnFailure"
+	// Delete the hook object if it became ready, or failed.
+	DeleteHookCleanupPolicyAlways DeleteHookCleanupPolicy = "Always"
+)
+
 // MissingNamespacesPolicy defines what the reconciler does if namespaces of dependent objects are not existing.
 type MissingNamespacesPolicy string
 
@@ -146,4 +173,30 @@ const (
 	PhaseCompleting              = "Completing"
 	PhaseReady                   = "Ready"
 	PhaseCompleted               = "Completed"
+	PhaseRunningHook             = "RunningHook"
+	PhaseHookSucceeded           = "HookSucceeded"
+	PhaseHookFailed              = "HookFailed"
+	PhaseScheduledForTest        = "ScheduledForTest"
+	PhaseTesting                 = "Testing"
+	PhaseTestSucceeded           = "TestSucceeded"
+	PhaseTestFailed              = "TestFailed"
+)
+
+// PlanAction defines the change which is planned for a dependent object.
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "Create"
+	PlanActionUpdate PlanAction = "Update"
+	PlanActionDelete PlanAction = "Delete"
 )
+
+// PlanItem represents a planned change of a dependent object.
+type PlanItem struct {
+	// Type of the dependent object.
+	TypeVersionInfo `json:",inline"`
+	// Namespace and name of the dependent object.
+	NameInfo `json:",inline"`
+	// Planned action.
+	Action PlanAction `json:"action"`
+}
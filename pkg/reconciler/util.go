@@ -78,6 +78,10 @@ func isSecret(key types.ObjectKey) bool {
 	return key.GetObjectKind().GroupVersionKind().GroupKind() == schema.GroupKind{Group: "", Kind: "Secret"}
 }
 
+func isPod(key types.ObjectKey) bool {
+	return key.GetObjectKind().GroupVersionKind().GroupKind() == schema.GroupKind{Group: "", Kind: "Pod"}
+}
+
 func getCrds(objects []client.Object) []*apiextensionsv1.CustomResourceDefinition {
 	var crds []*apiextensionsv1.CustomResourceDefinition
 	for _, object := range objects {
@@ -304,6 +308,14 @@ func mustGetItem(inventory []*InventoryItem, key types.ObjectKey) *InventoryItem
 	return item
 }
 
+func isHookItem(item *InventoryItem) bool {
+	return item.Phase == PhaseRunningHook || item.Phase == PhaseHookSucceeded || item.Phase == PhaseHookFailed
+}
+
+func isTestItem(item *InventoryItem) bool {
+	return item.Phase == PhaseScheduledForTest || item.Phase == PhaseTesting || item.Phase == PhaseTestSucceeded || item.Phase == PhaseTestFailed
+}
+
 func isNamespaceUsed(inventory []*InventoryItem, namespace string) bool {
 	// TODO: do not consider inventory items with certain Phases (e.g. Completed)?
 	for _, item := range inventory {
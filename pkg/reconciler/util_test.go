@@ -853,6 +853,24 @@ var _ = Describe("testing: util.go", func() {
 
 	})
 
+	Describe("testing: isHookItem(), isTestItem()", func() {
+
+		It("should only treat regular dependents as relevant for readiness", func() {
+			for _, phase := range []Phase{PhaseScheduledForApplication, PhaseCreating, PhaseUpdating, PhaseReady, PhaseCompleted} {
+				Expect((InventoryItem{Phase: phase}).IsRegular()).To(BeTrue())
+			}
+			for _, phase := range []Phase{PhaseRunningHook, PhaseHookSucceeded} {
+				Expect(isHookItem(&InventoryItem{Phase: phase})).To(BeTrue())
+				Expect((InventoryItem{Phase: phase}).IsRegular()).To(BeFalse())
+			}
+			for _, phase := range []Phase{PhaseScheduledForTest, PhaseTesting, PhaseTestSucceeded, PhaseTestFailed} {
+				Expect(isTestItem(&InventoryItem{Phase: phase})).To(BeTrue())
+				Expect((InventoryItem{Phase: phase}).IsRegular()).To(BeFalse())
+			}
+		})
+
+	})
+
 	Describe("testing: matches()", func() {
 
 		It("should match", func() {
@@ -0,0 +1,65 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// StatusAnalyzerFunc is an adapter allowing to use ordinary functions as StatusAnalyzer.
+type StatusAnalyzerFunc func(object *unstructured.Unstructured) (Status, error)
+
+// Implement the StatusAnalyzer interface.
+func (f StatusAnalyzerFunc) ComputeStatus(object *unstructured.Unstructured) (Status, error) {
+	return f(object)
+}
+
+// StatusAnalyzerRegistry is a StatusAnalyzer which dispatches to analyzers registered for specific types,
+// and falls back to a default analyzer for all other types.
+type StatusAnalyzerRegistry struct {
+	mutex           sync.RWMutex
+	defaultAnalyzer StatusAnalyzer
+	analyzers       map[schema.GroupVersionKind]StatusAnalyzer
+}
+
+var _ StatusAnalyzer = &StatusAnalyzerRegistry{}
+
+// Create a new StatusAnalyzerRegistry, using the given default analyzer for types without a registered analyzer.
+func NewStatusAnalyzerRegistry(defaultAnalyzer StatusAnalyzer) *StatusAnalyzerRegistry {
+	return &StatusAnalyzerRegistry{
+		defaultAnalyzer: defaultAnalyzer,
+		analyzers:       make(map[schema.GroupVersionKind]StatusAnalyzer),
+	}
+}
+
+// Register analyzer for given type. If the version of the passed type is empty, then the analyzer
+// is used for all versions of the type (unless there is an analyzer registered for the specific version).
+func (r *StatusAnalyzerRegistry) Register(gvk schema.GroupVersionKind, analyzer StatusAnalyzer) *StatusAnalyzerRegistry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.analyzers[gvk] = analyzer
+	return r
+}
+
+// Implement the StatusAnalyzer interface.
+func (r *StatusAnalyzerRegistry) ComputeStatus(object *unstructured.Unstructured) (Status, error) {
+	return r.getAnalyzer(object.GroupVersionKind()).ComputeStatus(object)
+}
+
+func (r *StatusAnalyzerRegistry) getAnalyzer(gvk schema.GroupVersionKind) StatusAnalyzer {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if analyzer, ok := r.analyzers[gvk]; ok {
+		return analyzer
+	}
+	if analyzer, ok := r.analyzers[gvk.GroupKind().WithVersion("")]; ok {
+		return analyzer
+	}
+	return r.defaultAnalyzer
+}
@@ -0,0 +1,48 @@
+/*
+SPDX-FileCopyrightText: 2026 SAP SE or an SAP affiliate company and component-operator-runtime contributors
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package status_test
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/sap/component-operator-runtime/pkg/status"
+)
+
+var _ = Describe("testing: registry.go", func() {
+	var registry *status.StatusAnalyzerRegistry
+
+	newAnalyzer := func(result status.Status) status.StatusAnalyzer {
+		return status.StatusAnalyzerFunc(func(object *unstructured.Unstructured) (status.Status, error) {
+			return result, nil
+		})
+	}
+
+	newObject := func(apiVersion string, kind string) *unstructured.Unstructured {
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion(apiVersion)
+		object.SetKind(kind)
+		return object
+	}
+
+	BeforeEach(func() {
+		registry = status.NewStatusAnalyzerRegistry(newAnalyzer(status.UnknownStatus)).
+			Register(schema.GroupVersionKind{Group: "example.io", Kind: "Foo"}, newAnalyzer(status.InProgressStatus)).
+			Register(schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "Foo"}, newAnalyzer(status.CurrentStatus))
+	})
+
+	DescribeTable("testing: ComputeStatus()",
+		func(apiVersion string, kind string, expectedStatus status.Status) {
+			Expect(registry.ComputeStatus(newObject(apiVersion, kind))).To(Equal(expectedStatus))
+		},
+		Entry(nil, "example.io/v1", "Foo", status.CurrentStatus),
+		Entry(nil, "example.io/v2", "Foo", status.InProgressStatus),
+		Entry(nil, "example.io/v1", "Bar", status.UnknownStatus),
+	)
+})
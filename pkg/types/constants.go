@@ -6,19 +6,30 @@ SPDX-License-Identifier: Apache-2.0
 package types
 
 const (
-	LabelKeySuffixOwnerId              = "owner-id"
-	AnnotationKeySuffixOwnerId         = "owner-id"
-	AnnotationKeySuffixDigest          = "digest"
-	AnnotationKeySuffixAdoptionPolicy  = "adoption-policy"
-	AnnotationKeySuffixReconcilePolicy = "reconcile-policy"
-	AnnotationKeySuffixUpdatePolicy    = "update-policy"
-	AnnotationKeySuffixDeletePolicy    = "delete-policy"
-	AnnotationKeySuffixReapplyInterval = "reapply-interval"
-	AnnotationKeySuffixApplyOrder      = "apply-order"
-	AnnotationKeySuffixPurgeOrder      = "purge-order"
-	AnnotationKeySuffixDeleteOrder     = "delete-order"
-	AnnotationKeySuffixStatusHint      = "status-hint"
-	AnnotationKeySuffixDisableEvents   = "disable-events"
+	LabelKeySuffixOwnerId                      = "owner-id"
+	LabelKeySuffixShard                        = "shard"
+	LabelKeySuffixShardGroup                   = "shard-group"
+	AnnotationKeySuffixOwnerId                 = "owner-id"
+	AnnotationKeySuffixDigest                  = "digest"
+	AnnotationKeySuffixAdoptionPolicy          = "adoption-policy"
+	AnnotationKeySuffixReconcilePolicy         = "reconcile-policy"
+	AnnotationKeySuffixUpdatePolicy            = "update-policy"
+	AnnotationKeySuffixDeletePolicy            = "delete-policy"
+	AnnotationKeySuffixReapplyInterval         = "reapply-interval"
+	AnnotationKeySuffixApplyOrder              = "apply-order"
+	AnnotationKeySuffixPurgeOrder              = "purge-order"
+	AnnotationKeySuffixDeleteOrder             = "delete-order"
+	AnnotationKeySuffixStatusHint              = "status-hint"
+	AnnotationKeySuffixDisableEvents           = "disable-events"
+	AnnotationKeySuffixReferenceGrant          = "reference-grant"
+	AnnotationKeySuffixOutputs                 = "outputs"
+	AnnotationKeySuffixSensitiveOutputs        = "sensitive-outputs"
+	AnnotationKeySuffixApprovedPlan            = "approved-plan"
+	AnnotationKeySuffixRetry                   = "retry"
+	AnnotationKeySuffixDeleteHook              = "delete-hook"
+	AnnotationKeySuffixDeleteHookCleanupPolicy = "delete-hook-cleanup-policy"
+	AnnotationKeySuffixTestHook                = "test-hook"
+	AnnotationKeySuffixSkipDeleteHooks         = "skip-delete-hooks"
 )
 
 const (
@@ -49,6 +60,18 @@ const (
 	DeletePolicyOrphanOnDelete = "orphan-on-delete"
 )
 
+const (
+	DeleteHookPreDelete  = "pre-delete"
+	DeleteHookPostDelete = "post-delete"
+)
+
+const (
+	DeleteHookCleanupPolicyNever     = "never"
+	DeleteHookCleanupPolicyOnSuccess = "on-success"
+	DeleteHookCleanupPolicyOnFailure = "on-failure"
+	DeleteHookCleanupPolicyAlways    = "always"
+)
+
 const (
 	StatusHintHasObservedGeneration = "has-observed-generation"
 	StatusHintHasReadyCondition     = "has-ready-condition"
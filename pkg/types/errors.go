@@ -7,15 +7,27 @@ package types
 
 import "time"
 
+// RetriableError indicates that the reconciliation should be retried after the specified delay
+// (instead of applying the default backoff); the component will be set to a Pending (or DeletionPending) state.
 type RetriableError struct {
 	err        error
 	retryAfter *time.Duration
+	reason     string
+	message    string
 }
 
 func NewRetriableError(err error, retryAfter *time.Duration) RetriableError {
 	return RetriableError{err: err, retryAfter: retryAfter}
 }
 
+// Return a copy of the error, carrying the given reason and message (to be used in the component's status instead of the defaults).
+// Empty values mean that the according default is used.
+func (e RetriableError) WithReason(reason string, message string) RetriableError {
+	e.reason = reason
+	e.message = message
+	return e
+}
+
 func (e RetriableError) Error() string {
 	return e.err.Error()
 }
@@ -31,3 +43,98 @@ func (e RetriableError) Cause() error {
 func (e RetriableError) RetryAfter() *time.Duration {
 	return e.retryAfter
 }
+
+func (e RetriableError) Reason() string {
+	return e.reason
+}
+
+func (e RetriableError) Message() string {
+	return e.message
+}
+
+// ErrorState is the state which a component shall enter if a ReconcileError is returned.
+type ErrorState string
+
+const (
+	// The component is set to a Pending (or, if being deleted, DeletionPending) state, and the reconciliation is retried
+	// after the specified delay (or the component's retry interval); no error is returned to controller-runtime.
+	ErrorStatePending ErrorState = "Pending"
+	// The component is set to an Error state; if a delay is specified, the reconciliation is retried after that delay,
+	// otherwise the error is returned to controller-runtime (such that the reconciliation is retried with backoff).
+	ErrorStateError ErrorState = "Error"
+)
+
+// ReconcileError is an error which carries details how it should be reflected in the status of the component;
+// it may be returned by generators and hooks (as is, or wrapped).
+type ReconcileError struct {
+	err        error
+	state      ErrorState
+	reason     string
+	message    string
+	retryAfter *time.Duration
+	noEvent    bool
+}
+
+// Create a new ReconcileError. Empty reason or message mean that the according default is used (which is the error message in case of message).
+func NewReconcileError(err error, state ErrorState, reason string, message string) ReconcileError {
+	return ReconcileError{err: err, state: state, reason: reason, message: message}
+}
+
+// Create a new ReconcileError, making the component Pending, and the reconciliation being retried after the given delay.
+// If retryAfter is nil, the retry interval of the component is used.
+func NewPendingError(err error, reason string, message string, retryAfter *time.Duration) ReconcileError {
+	return NewReconcileError(err, ErrorStatePending, reason, message).withRetryAfter(retryAfter)
+}
+
+// Create a new ReconcileError, making the component go into an Error state.
+func NewFailedError(err error, reason string, message string) ReconcileError {
+	return NewReconcileError(err, ErrorStateError, reason, message)
+}
+
+// Return a copy of the error with the given retry delay.
+func (e ReconcileError) WithRetryAfter(retryAfter time.Duration) ReconcileError {
+	return e.withRetryAfter(&retryAfter)
+}
+
+// Return a copy of the error which does not cause an event to be emitted for the component.
+func (e ReconcileError) WithoutEvent() ReconcileError {
+	e.noEvent = true
+	return e
+}
+
+func (e ReconcileError) withRetryAfter(retryAfter *time.Duration) ReconcileError {
+	e.retryAfter = retryAfter
+	return e
+}
+
+func (e ReconcileError) Error() string {
+	return e.err.Error()
+}
+
+func (e ReconcileError) Unwrap() error {
+	return e.err
+}
+
+func (e ReconcileError) Cause() error {
+	return e.err
+}
+
+func (e ReconcileError) State() ErrorState {
+	return e.state
+}
+
+func (e ReconcileError) Reason() string {
+	return e.reason
+}
+
+func (e ReconcileError) Message() string {
+	return e.message
+}
+
+func (e ReconcileError) RetryAfter() *time.Duration {
+	return e.retryAfter
+}
+
+func (e ReconcileError) EmitEvent() bool {
+	return !e.noEvent
+}
@@ -47,6 +47,42 @@ var _ = Describe("testing: errors.go", func() {
 		Expect(errors.Is(outerError, unwrappedRetriableError)).To(BeTrue())
 	})
 
+	It("should return a RetriableError with custom reason and message", func() {
+		err := CustomError{Message: "test error"}
+
+		rerr := types.NewRetriableError(err, nil).WithReason("Waiting", "Waiting for something")
+		Expect(rerr.Error()).To(Equal("test error"))
+		Expect(rerr.Reason()).To(Equal("Waiting"))
+		Expect(rerr.Message()).To(Equal("Waiting for something"))
+		Expect(rerr.RetryAfter()).To(BeNil())
+	})
+
+	It("should return valid ReconcileErrors", func() {
+		err := CustomError{Message: "test error"}
+
+		perr := types.NewPendingError(err, "Waiting", "", new(5*time.Second))
+		Expect(perr.Error()).To(Equal("test error"))
+		Expect(perr.Unwrap()).To(Equal(err))
+		Expect(perr.State()).To(Equal(types.ErrorStatePending))
+		Expect(perr.Reason()).To(Equal("Waiting"))
+		Expect(perr.Message()).To(BeEmpty())
+		Expect(perr.RetryAfter()).To(Equal(new(5 * time.Second)))
+		Expect(perr.EmitEvent()).To(BeTrue())
+
+		ferr := types.NewFailedError(err, "InvalidSpec", "Spec is invalid").WithoutEvent()
+		Expect(ferr.State()).To(Equal(types.ErrorStateError))
+		Expect(ferr.Reason()).To(Equal("InvalidSpec"))
+		Expect(ferr.Message()).To(Equal("Spec is invalid"))
+		Expect(ferr.RetryAfter()).To(BeNil())
+		Expect(ferr.EmitEvent()).To(BeFalse())
+		Expect(ferr.WithRetryAfter(time.Minute).RetryAfter()).To(Equal(new(time.Minute)))
+		Expect(ferr.RetryAfter()).To(BeNil())
+
+		unwrappedReconcileError, ok := errors.AsType[types.ReconcileError](fmt.Errorf("outer error: %w", ferr))
+		Expect(ok).To(BeTrue())
+		Expect(unwrappedReconcileError).To(Equal(ferr))
+	})
+
 })
 
 type CustomError struct {